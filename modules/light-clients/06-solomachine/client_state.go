@@ -0,0 +1,175 @@
+package solomachine
+
+import (
+	errorsmod "cosmossdk.io/errors"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
+	"github.com/cosmos/ibc-go/v8/modules/core/exported"
+)
+
+var (
+	_ exported.ClientState               = (*ClientState)(nil)
+	_ codectypes.UnpackInterfacesMessage = (*ClientState)(nil)
+)
+
+// ClientState defines a solo machine client that tracks the current sequence, frozen status,
+// and latest consensus state of a single signing authority (an externally owned key, or a
+// threshold of keys - see ConsensusState for the committed public key material).
+type ClientState struct {
+	Sequence                 uint64          `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence"`
+	IsFrozen                 bool            `protobuf:"varint,2,opt,name=is_frozen,json=isFrozen,proto3" json:"is_frozen"`
+	ConsensusState           *ConsensusState `protobuf:"bytes,3,opt,name=consensus_state,json=consensusState,proto3" json:"consensus_state"`
+	AllowUpdateAfterProposal bool            `protobuf:"varint,4,opt,name=allow_update_after_proposal,json=allowUpdateAfterProposal,proto3" json:"allow_update_after_proposal,omitempty"` // Deprecated: no longer used
+}
+
+// UnpackInterfaces implements codectypes.UnpackInterfacesMessage, delegating to ConsensusState to
+// resolve its PublicKey once this ClientState has been unmarshalled through a codec whose
+// InterfaceRegistry has RegisterInterfaces applied.
+func (cs ClientState) UnpackInterfaces(unpacker codectypes.AnyUnpacker) error {
+	if cs.ConsensusState == nil {
+		return nil
+	}
+
+	return cs.ConsensusState.UnpackInterfaces(unpacker)
+}
+
+// Marshal implements the gogoproto Marshaler interface. See wire.go.
+func (cs *ClientState) Marshal() ([]byte, error) {
+	if cs == nil {
+		return nil, nil
+	}
+
+	var dst []byte
+	if cs.Sequence != 0 {
+		dst = appendVarintField(dst, 1, cs.Sequence)
+	}
+	if cs.IsFrozen {
+		dst = appendBoolField(dst, 2, cs.IsFrozen)
+	}
+	if cs.ConsensusState != nil {
+		bz, err := cs.ConsensusState.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		dst = appendBytesField(dst, 3, bz)
+	}
+	if cs.AllowUpdateAfterProposal {
+		dst = appendBoolField(dst, 4, cs.AllowUpdateAfterProposal)
+	}
+
+	return dst, nil
+}
+
+// Size implements the gogoproto Sizer interface. See wire.go.
+func (cs *ClientState) Size() int {
+	if cs == nil {
+		return 0
+	}
+
+	var n int
+	if cs.Sequence != 0 {
+		n += sizeVarintField(1, cs.Sequence)
+	}
+	if cs.IsFrozen {
+		n += sizeVarintField(2, 1)
+	}
+	if cs.ConsensusState != nil {
+		n += sizeEmbedded(3, cs.ConsensusState.Size())
+	}
+	if cs.AllowUpdateAfterProposal {
+		n += sizeVarintField(4, 1)
+	}
+
+	return n
+}
+
+// Unmarshal implements the gogoproto Unmarshaler interface. See wire.go.
+func (cs *ClientState) Unmarshal(bz []byte) error {
+	*cs = ClientState{}
+
+	for len(bz) > 0 {
+		f, rest, err := nextField(bz)
+		if err != nil {
+			return err
+		}
+		bz = rest
+
+		switch f.num {
+		case 1:
+			cs.Sequence = f.varint
+		case 2:
+			cs.IsFrozen = f.varint != 0
+		case 3:
+			var inner ConsensusState
+			if err := inner.Unmarshal(f.bytes); err != nil {
+				return err
+			}
+			cs.ConsensusState = &inner
+		case 4:
+			cs.AllowUpdateAfterProposal = f.varint != 0
+		}
+	}
+
+	return nil
+}
+
+// ClientType is Solo Machine.
+func (ClientState) ClientType() string {
+	return exported.Solomachine
+}
+
+// Validate performs basic validation of the client state fields.
+func (cs ClientState) Validate() error {
+	if cs.Sequence == 0 {
+		return errorsmod.Wrap(ErrInvalidSequence, "sequence cannot be 0")
+	}
+
+	if cs.ConsensusState == nil {
+		return errorsmod.Wrap(ErrInvalidHeader, "consensus state cannot be nil")
+	}
+
+	return cs.ConsensusState.ValidateBasic()
+}
+
+// Status returns Frozen if the client is frozen, and Active otherwise. Solo machine clients have
+// no notion of expiry, so Expired is never returned by this method; it remains a valid target
+// status for SelfValidator/RecoverClient policies operating on clients recovered through other
+// means.
+func (cs ClientState) Status(_ sdk.Context, _ storetypes.KVStore, _ codec.BinaryCodec) exported.Status {
+	if cs.IsFrozen {
+		return exported.Frozen
+	}
+
+	return exported.Active
+}
+
+// Initialize replaces the solo machine client's consensus state with the provided initial
+// consensus state and sets the resulting client state, which embeds it, in the provided store. It
+// does not check that the two consensus states were equal beforehand.
+func (cs ClientState) Initialize(_ sdk.Context, cdc codec.BinaryCodec, clientStore storetypes.KVStore, consState exported.ConsensusState) error {
+	consensusState, ok := consState.(*ConsensusState)
+	if !ok {
+		return errorsmod.Wrapf(clienttypes.ErrInvalidConsensus, "invalid consensus state type for solo machine client, expected: %T, got: %T", &ConsensusState{}, consState)
+	}
+
+	cs.ConsensusState = consensusState
+
+	clientStore.Set(host.ClientStateKey(), clienttypes.MustMarshalClientState(cdc, &cs))
+
+	return nil
+}
+
+// GetTimestampAtHeight returns the timestamp of the latest (and only) consensus state.
+func (cs ClientState) GetTimestampAtHeight(_ sdk.Context, _ storetypes.KVStore, _ codec.BinaryCodec, _ exported.Height) (uint64, error) {
+	if cs.ConsensusState == nil {
+		return 0, errorsmod.Wrap(ErrInvalidHeader, "consensus state is nil")
+	}
+
+	return cs.ConsensusState.GetTimestamp(), nil
+}
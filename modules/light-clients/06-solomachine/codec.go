@@ -0,0 +1,33 @@
+package solomachine
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+)
+
+// RegisterInterfaces registers the concrete types ConsensusState.PublicKey may be packed as: the
+// standard cryptotypes.PubKey implementations for the single-signer mode, and ThresholdPublicKey
+// for a multi-signer committee (see threshold.go). Chain developers must call this on the app's
+// InterfaceRegistry, the same as every other module's RegisterInterfaces, for an Any packing a
+// ThresholdPublicKey to resolve back to its concrete type once unmarshalled.
+func RegisterInterfaces(registry codectypes.InterfaceRegistry) {
+	cryptocodec.RegisterInterfaces(registry)
+	registry.RegisterImplementations((*cryptotypes.PubKey)(nil), &ThresholdPublicKey{})
+}
+
+// ModuleCdc is used to marshal SignBytes prior to signature verification, matching the JSON
+// encoding that relayers and solo machine signing clients use to produce signatures, and to
+// marshal/unmarshal the threshold-mode types in threshold.go. Its InterfaceRegistry has the same
+// implementations registered as RegisterInterfaces, so a ConsensusState.PublicKey Any decoded
+// through ModuleCdc resolves to its concrete type the same way it would through the app's own
+// codec.
+var ModuleCdc = codec.NewProtoCodec(newModuleInterfaceRegistry())
+
+func newModuleInterfaceRegistry() codectypes.InterfaceRegistry {
+	registry := codectypes.NewInterfaceRegistry()
+	RegisterInterfaces(registry)
+
+	return registry
+}
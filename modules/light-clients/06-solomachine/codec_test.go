@@ -0,0 +1,39 @@
+package solomachine_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+
+	solomachine "github.com/cosmos/ibc-go/v8/modules/light-clients/06-solomachine"
+)
+
+// TestModuleCdc_ResolvesThresholdPublicKeyAny asserts that ModuleCdc's InterfaceRegistry actually
+// resolves a ThresholdPublicKey Any on unmarshal, rather than relying on the cached value a
+// same-process NewAnyWithValue call leaves behind. A fresh Any populated only by UnmarshalJSON
+// (as happens when a relayer-submitted proof or consensus state crosses a process boundary) can
+// only resolve GetCachedValue() if ThresholdPublicKey is registered on the codec's registry.
+func TestModuleCdc_ResolvesThresholdPublicKeyAny(t *testing.T) {
+	privKey := secp256k1.GenPrivKey()
+	participantAny, err := codectypes.NewAnyWithValue(privKey.PubKey())
+	require.NoError(t, err)
+
+	tpk := &solomachine.ThresholdPublicKey{Threshold: 1, Participants: []*codectypes.Any{participantAny}}
+	tpkAny, err := codectypes.NewAnyWithValue(tpk)
+	require.NoError(t, err)
+
+	bz, err := solomachine.ModuleCdc.MarshalJSON(tpkAny)
+	require.NoError(t, err)
+
+	// a fresh Any, never touched by NewAnyWithValue - its cached value can only come from
+	// ModuleCdc's registry resolving the type URL during UnmarshalJSON.
+	var reloaded codectypes.Any
+	require.NoError(t, solomachine.ModuleCdc.UnmarshalJSON(bz, &reloaded))
+
+	resolved, ok := reloaded.GetCachedValue().(*solomachine.ThresholdPublicKey)
+	require.True(t, ok)
+	require.Equal(t, tpk.Threshold, resolved.Threshold)
+}
@@ -0,0 +1,151 @@
+package solomachine
+
+import (
+	errorsmod "cosmossdk.io/errors"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+
+	"github.com/cosmos/ibc-go/v8/modules/core/exported"
+)
+
+var (
+	_ exported.ConsensusState            = (*ConsensusState)(nil)
+	_ codectypes.UnpackInterfacesMessage = (*ConsensusState)(nil)
+)
+
+// ConsensusState defines a solo machine consensus state. The consensus state of a solo machine
+// is simply the current public key together with the diversifier used to separate signing
+// namespaces between chains sharing a key, and the timestamp at which the key was bound.
+type ConsensusState struct {
+	PublicKey   *codectypes.Any `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key"`
+	Diversifier string          `protobuf:"bytes,2,opt,name=diversifier,proto3" json:"diversifier"`
+	Timestamp   uint64          `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp"`
+}
+
+// UnpackInterfaces implements codectypes.UnpackInterfacesMessage, resolving PublicKey's concrete
+// type (a cryptotypes.PubKey, or a ThresholdPublicKey committee) once this ConsensusState has
+// been unmarshalled through a codec whose InterfaceRegistry has RegisterInterfaces applied.
+func (cs ConsensusState) UnpackInterfaces(unpacker codectypes.AnyUnpacker) error {
+	var pubKey cryptotypes.PubKey
+	return unpacker.UnpackAny(cs.PublicKey, &pubKey)
+}
+
+// Marshal implements the gogoproto Marshaler interface. See wire.go.
+func (cs *ConsensusState) Marshal() ([]byte, error) {
+	if cs == nil {
+		return nil, nil
+	}
+
+	var dst []byte
+	if cs.PublicKey != nil {
+		bz, err := cs.PublicKey.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		dst = appendBytesField(dst, 1, bz)
+	}
+	if cs.Diversifier != "" {
+		dst = appendStringField(dst, 2, cs.Diversifier)
+	}
+	if cs.Timestamp != 0 {
+		dst = appendVarintField(dst, 3, cs.Timestamp)
+	}
+
+	return dst, nil
+}
+
+// Size implements the gogoproto Sizer interface. See wire.go.
+func (cs *ConsensusState) Size() int {
+	if cs == nil {
+		return 0
+	}
+
+	var n int
+	if cs.PublicKey != nil {
+		n += sizeEmbedded(1, cs.PublicKey.Size())
+	}
+	if cs.Diversifier != "" {
+		n += sizeStringField(2, cs.Diversifier)
+	}
+	if cs.Timestamp != 0 {
+		n += sizeVarintField(3, cs.Timestamp)
+	}
+
+	return n
+}
+
+// Unmarshal implements the gogoproto Unmarshaler interface. See wire.go.
+func (cs *ConsensusState) Unmarshal(bz []byte) error {
+	*cs = ConsensusState{}
+
+	for len(bz) > 0 {
+		f, rest, err := nextField(bz)
+		if err != nil {
+			return err
+		}
+		bz = rest
+
+		switch f.num {
+		case 1:
+			var any codectypes.Any
+			if err := any.Unmarshal(f.bytes); err != nil {
+				return err
+			}
+			cs.PublicKey = &any
+		case 2:
+			cs.Diversifier = string(f.bytes)
+		case 3:
+			cs.Timestamp = f.varint
+		}
+	}
+
+	return nil
+}
+
+// ClientType returns Solo Machine type.
+func (ConsensusState) ClientType() string {
+	return exported.Solomachine
+}
+
+// GetTimestamp returns the timestamp at which the current public key was bound.
+func (cs ConsensusState) GetTimestamp() uint64 {
+	return cs.Timestamp
+}
+
+// GetPubKey unmarshals the public key into a cryptotypes.PubKey type.
+func (cs ConsensusState) GetPubKey() (cryptotypes.PubKey, error) {
+	publicKey, ok := cs.PublicKey.GetCachedValue().(cryptotypes.PubKey)
+	if !ok {
+		return nil, errorsmod.Wrapf(ErrInvalidPubKey, "consensus state PublicKey is not cryptotypes.PubKey")
+	}
+
+	return publicKey, nil
+}
+
+// GetDiversifier returns the solo machine diversifier.
+func (cs ConsensusState) GetDiversifier() string {
+	return cs.Diversifier
+}
+
+// ValidateBasic defines basic validation for the solo machine consensus state. The public key may
+// be either a single cryptotypes.PubKey or a ThresholdPublicKey committee.
+func (cs ConsensusState) ValidateBasic() error {
+	if cs.PublicKey == nil || cs.PublicKey.GetCachedValue() == nil {
+		return errorsmod.Wrap(ErrInvalidPubKey, "consensus state public key cannot be nil")
+	}
+
+	if tpk, ok := cs.GetThresholdPubKey(); ok {
+		if err := tpk.ValidateBasic(); err != nil {
+			return errorsmod.Wrap(err, "consensus state threshold public key is invalid")
+		}
+	} else if _, err := cs.GetPubKey(); err != nil {
+		return errorsmod.Wrap(err, "consensus state public key is invalid")
+	}
+
+	if cs.Timestamp == 0 {
+		return errorsmod.Wrap(ErrInvalidHeader, "consensus state timestamp cannot be 0")
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package solomachine
+
+import (
+	errorsmod "cosmossdk.io/errors"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
+	"github.com/cosmos/ibc-go/v8/modules/light-clients/06-solomachine/keeper"
+)
+
+// clientStateDeltaKey is the store key under which a ClientStateDelta is persisted for a client
+// running in ephemeral mode. Its presence in a client's store is what marks the client as
+// ephemeral: loadClientState checks for it before falling back to the legacy full ClientState key,
+// so the two storage modes can coexist on the same chain.
+var clientStateDeltaKey = []byte("clientStateDelta")
+
+// ClientStateDelta holds the only per-client state that cannot be resolved from the keeper's
+// EphemeralRegistry: the sequence, frozen flag, and current signing timestamp. The public key and
+// diversifier - the bulk of a ConsensusState's bytes, and the part many clients created from the
+// same custodian key share byte-for-byte - are never stored per client; they live once in the
+// registry, keyed by TemplateID, and are resolved at load time. This is what makes ephemeral mode
+// actually reduce per-client storage, rather than merely relocating the same bytes.
+type ClientStateDelta struct {
+	Sequence   uint64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence"`
+	IsFrozen   bool   `protobuf:"varint,2,opt,name=is_frozen,json=isFrozen,proto3" json:"is_frozen"`
+	Timestamp  uint64 `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp"`
+	TemplateID string `protobuf:"bytes,4,opt,name=template_id,json=templateId,proto3" json:"template_id"`
+}
+
+func (d *ClientStateDelta) Reset()         { *d = ClientStateDelta{} }
+func (d *ClientStateDelta) String() string { return "" }
+func (*ClientStateDelta) ProtoMessage()    {}
+
+// loadClientState loads a client's ClientState, reconstructing it from a ClientStateDelta and the
+// keeper's EphemeralRegistry if the client was created in ephemeral mode, or unmarshalling the
+// legacy full ClientState blob otherwise. It replaces the store.Get(host.ClientStateKey()) +
+// Unmarshal pattern that used to be repeated in every LightClientModule method, the same way the
+// 09-localhost stateless refactor replaced its equivalent per-method boilerplate.
+func loadClientState(cdc codec.BinaryCodec, registry keeper.EphemeralRegistry, store storetypes.KVStore, clientID string) (ClientState, error) {
+	if registry != nil {
+		if deltaBz := store.Get(clientStateDeltaKey); len(deltaBz) != 0 {
+			var delta ClientStateDelta
+			if err := cdc.Unmarshal(deltaBz, &delta); err != nil {
+				return ClientState{}, err
+			}
+
+			pubKey, diversifier, found := registry.Resolve(delta.TemplateID)
+			if !found {
+				return ClientState{}, errorsmod.Wrapf(ErrInvalidClientMessage, "no ephemeral template registered for client ID: %s", clientID)
+			}
+
+			// A registry backend that does not preserve Go object identity across Register and
+			// Resolve (e.g. KVStoreEphemeralRegistry, which re-decodes the Any from the store on
+			// every Resolve) returns an Any whose cached value has not been hydrated. Resolve this
+			// here rather than in the registry, since EphemeralRegistry.Resolve has no cdc/registry
+			// parameter to do so itself.
+			if pubKey.GetCachedValue() == nil {
+				var pk cryptotypes.PubKey
+				if err := cdc.UnpackAny(pubKey, &pk); err != nil {
+					return ClientState{}, err
+				}
+			}
+
+			return ClientState{
+				Sequence: delta.Sequence,
+				IsFrozen: delta.IsFrozen,
+				ConsensusState: &ConsensusState{
+					PublicKey:   pubKey,
+					Diversifier: diversifier,
+					Timestamp:   delta.Timestamp,
+				},
+			}, nil
+		}
+	}
+
+	bz := store.Get(host.ClientStateKey())
+	if len(bz) == 0 {
+		return ClientState{}, errorsmod.Wrapf(clienttypes.ErrClientNotFound, "client ID: %s", clientID)
+	}
+
+	var clientState ClientState
+	if err := cdc.Unmarshal(bz, &clientState); err != nil {
+		return ClientState{}, err
+	}
+
+	return clientState, nil
+}
+
+// storeEphemeralClientState persists cs using the ephemeral representation: a ClientStateDelta
+// under clientStateDeltaKey, referencing a template registered for cs.ConsensusState's current
+// public key and diversifier. registry.Register is idempotent and content-addressed (see
+// InMemoryEphemeralRegistry), so every client whose current key and diversifier happen to match -
+// whether from creation or from having rotated to the same key - shares one template rather than
+// each storing its own copy. Callers must call this again (re-registering) whenever cs's public
+// key, diversifier, or timestamp change, e.g. after UpdateState rotates the signing key.
+func storeEphemeralClientState(cdc codec.BinaryCodec, registry keeper.EphemeralRegistry, store storetypes.KVStore, cs ClientState) error {
+	templateID, err := registry.Register(cs.ConsensusState.PublicKey, cs.ConsensusState.Diversifier)
+	if err != nil {
+		return err
+	}
+
+	delta := ClientStateDelta{
+		Sequence:   cs.Sequence,
+		IsFrozen:   cs.IsFrozen,
+		Timestamp:  cs.ConsensusState.Timestamp,
+		TemplateID: templateID,
+	}
+	store.Set(clientStateDeltaKey, cdc.MustMarshal(&delta))
+
+	return nil
+}
+
+// MigrateToEphemeral converts an existing full ClientState in store into the ephemeral
+// ClientStateDelta representation, registering its current public key and diversifier with
+// registry. It is a no-op if the client is already ephemeral. Chain upgrade handlers should call
+// this once per existing solo machine client ID when adopting ephemeral mode.
+func MigrateToEphemeral(cdc codec.BinaryCodec, store storetypes.KVStore, registry keeper.EphemeralRegistry) error {
+	if deltaBz := store.Get(clientStateDeltaKey); len(deltaBz) != 0 {
+		return nil
+	}
+
+	bz := store.Get(host.ClientStateKey())
+	if len(bz) == 0 {
+		return errorsmod.Wrap(clienttypes.ErrClientNotFound, "no client state found to migrate")
+	}
+
+	var clientState ClientState
+	if err := cdc.Unmarshal(bz, &clientState); err != nil {
+		return err
+	}
+
+	if clientState.ConsensusState == nil {
+		return errorsmod.Wrap(ErrInvalidHeader, "client state has no consensus state to migrate")
+	}
+
+	if err := storeEphemeralClientState(cdc, registry, store, clientState); err != nil {
+		return err
+	}
+	store.Delete(host.ClientStateKey())
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package solomachine_test
+
+import (
+	"io"
+	"testing"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+
+	solomachine "github.com/cosmos/ibc-go/v8/modules/light-clients/06-solomachine"
+	"github.com/cosmos/ibc-go/v8/modules/light-clients/06-solomachine/keeper"
+)
+
+// memStore is a minimal in-memory storetypes.KVStore sufficient for exercising
+// solomachine.MigrateToEphemeral and the legacy/ephemeral loadClientState paths directly,
+// without needing a full chain/app test harness.
+type memStore struct {
+	data map[string][]byte
+}
+
+func newMemStore() *memStore { return &memStore{data: make(map[string][]byte)} }
+
+func (s *memStore) Get(key []byte) []byte { return s.data[string(key)] }
+func (s *memStore) Has(key []byte) bool   { _, ok := s.data[string(key)]; return ok }
+func (s *memStore) Set(key, value []byte) { s.data[string(key)] = value }
+func (s *memStore) Delete(key []byte)     { delete(s.data, string(key)) }
+func (s *memStore) Iterator(start, end []byte) storetypes.Iterator {
+	panic("not implemented")
+}
+
+func (s *memStore) ReverseIterator(start, end []byte) storetypes.Iterator {
+	panic("not implemented")
+}
+func (s *memStore) GetStoreType() storetypes.StoreType { return storetypes.StoreTypeIAVL }
+func (s *memStore) CacheWrap() storetypes.CacheWrap    { panic("not implemented") }
+func (s *memStore) CacheWrapWithTrace(w io.Writer, tc storetypes.TraceContext) storetypes.CacheWrap {
+	panic("not implemented")
+}
+
+func newTestClientState(t *testing.T) (codec.BinaryCodec, solomachine.ClientState) {
+	t.Helper()
+
+	cdc := newTestCodec()
+
+	privKey := secp256k1.GenPrivKey()
+	pubKeyAny, err := codectypes.NewAnyWithValue(privKey.PubKey())
+	require.NoError(t, err)
+
+	clientState := solomachine.ClientState{
+		Sequence: 1,
+		ConsensusState: &solomachine.ConsensusState{
+			PublicKey:   pubKeyAny,
+			Diversifier: "ibc",
+			Timestamp:   10,
+		},
+	}
+
+	return cdc, clientState
+}
+
+func newTestCodec() codec.BinaryCodec {
+	registry := codectypes.NewInterfaceRegistry()
+	cryptocodec.RegisterInterfaces(registry)
+
+	return codec.NewProtoCodec(registry)
+}
+
+// TestMigrateToEphemeral_StoresDeltaAndConsensusState asserts that after migration the legacy
+// full ClientState key is gone, the client's public key is no longer stored in its own store (it
+// lives only in the registry), and the client is still reconstructible via the ephemeral path.
+func TestMigrateToEphemeral_StoresDeltaAndConsensusState(t *testing.T) {
+	cdc, clientState := newTestClientState(t)
+
+	store := newMemStore()
+	store.Set(solomachine.ClientStateKeyForTest(), cdc.MustMarshal(&clientState))
+
+	registry := keeper.NewInMemoryEphemeralRegistry()
+
+	require.NoError(t, solomachine.MigrateToEphemeral(cdc, store, registry))
+	require.False(t, store.Has(solomachine.ClientStateKeyForTest()))
+
+	reloaded, err := solomachine.LoadClientStateForTest(cdc, registry, store, "06-solomachine-0")
+	require.NoError(t, err)
+	require.Equal(t, clientState.Sequence, reloaded.Sequence)
+	require.Equal(t, clientState.IsFrozen, reloaded.IsFrozen)
+	require.Equal(t, clientState.ConsensusState.Diversifier, reloaded.ConsensusState.Diversifier)
+
+	// the migrated client's own store no longer holds the public key at all - it is resolved
+	// from the registry's single shared template.
+	for _, value := range store.data {
+		require.NotContains(t, string(value), string(clientState.ConsensusState.PublicKey.Value))
+	}
+}
+
+// TestStoreEphemeralClientState_RotationResolvesNewKeyFromRegistry asserts that re-storing an
+// ephemeral client after its public key has rotated (e.g. via Header/HeaderBatch UpdateState)
+// re-registers the new key and that loading the client afterwards resolves the rotated key, not
+// the one it was created with.
+func TestStoreEphemeralClientState_RotationResolvesNewKeyFromRegistry(t *testing.T) {
+	cdc, clientState := newTestClientState(t)
+	registry := keeper.NewInMemoryEphemeralRegistry()
+	store := newMemStore()
+
+	require.NoError(t, solomachine.StoreEphemeralClientStateForTest(cdc, registry, store, clientState))
+
+	newPrivKey := secp256k1.GenPrivKey()
+	newPubKeyAny, err := codectypes.NewAnyWithValue(newPrivKey.PubKey())
+	require.NoError(t, err)
+
+	rotated := clientState
+	rotated.Sequence++
+	rotated.ConsensusState = &solomachine.ConsensusState{
+		PublicKey:   newPubKeyAny,
+		Diversifier: "ibc",
+		Timestamp:   11,
+	}
+
+	require.NoError(t, solomachine.StoreEphemeralClientStateForTest(cdc, registry, store, rotated))
+
+	reloaded, err := solomachine.LoadClientStateForTest(cdc, registry, store, "06-solomachine-0")
+	require.NoError(t, err)
+	require.Equal(t, rotated.Sequence, reloaded.Sequence)
+	require.Equal(t, uint64(11), reloaded.ConsensusState.Timestamp)
+
+	reloadedPubKey, ok := reloaded.ConsensusState.PublicKey.GetCachedValue().(cryptotypes.PubKey)
+	require.True(t, ok)
+	require.True(t, reloadedPubKey.Equals(newPrivKey.PubKey()))
+}
+
+// TestLoadClientState_KVStoreEphemeralRegistry_HydratesPublicKey asserts that loadClientState
+// resolves a usable cryptotypes.PubKey even when the registry is KVStoreEphemeralRegistry, whose
+// Resolve re-decodes the Any from the store on every call and therefore does not preserve Go
+// object identity (and so does not preserve the Any's cached value) the way
+// InMemoryEphemeralRegistry's in-process map does.
+func TestLoadClientState_KVStoreEphemeralRegistry_HydratesPublicKey(t *testing.T) {
+	cdc, clientState := newTestClientState(t)
+
+	registryStore := newMemStore()
+	registry := keeper.NewKVStoreEphemeralRegistry(registryStore)
+	clientStore := newMemStore()
+
+	require.NoError(t, solomachine.StoreEphemeralClientStateForTest(cdc, registry, clientStore, clientState))
+
+	reloaded, err := solomachine.LoadClientStateForTest(cdc, registry, clientStore, "06-solomachine-0")
+	require.NoError(t, err)
+
+	reloadedPubKey, ok := reloaded.ConsensusState.PublicKey.GetCachedValue().(cryptotypes.PubKey)
+	require.True(t, ok)
+
+	originalPubKey, ok := clientState.ConsensusState.PublicKey.GetCachedValue().(cryptotypes.PubKey)
+	require.True(t, ok)
+	require.True(t, reloadedPubKey.Equals(originalPubKey))
+}
+
+// BenchmarkClientStateStorage_LegacyVsEphemeral reports the total marshaled bytes stored across
+// N clients created from the same custodian public key: legacy mode embeds a full copy of that
+// key in every client's own ClientState, while ephemeral mode registers it once and has every
+// client store only a small ClientStateDelta referencing it. This is where ephemeral mode's
+// storage reduction actually comes from - a single client has no shared key to dedupe against,
+// so the per-client savings only materialize in aggregate.
+func BenchmarkClientStateStorage_LegacyVsEphemeral(b *testing.B) {
+	cdc := newTestCodec()
+
+	privKey := secp256k1.GenPrivKey()
+	pubKeyAny, err := codectypes.NewAnyWithValue(privKey.PubKey())
+	require.NoError(b, err)
+
+	const numClients = 1000
+
+	b.Run("legacy_full_client_state", func(b *testing.B) {
+		total := 0
+		for i := 0; i < numClients; i++ {
+			clientState := solomachine.ClientState{
+				Sequence: 42,
+				ConsensusState: &solomachine.ConsensusState{
+					PublicKey:   pubKeyAny,
+					Diversifier: "ibc",
+					Timestamp:   10,
+				},
+			}
+			total += len(cdc.MustMarshal(&clientState))
+		}
+		b.ReportMetric(float64(total)/numClients, "bytes/client")
+	})
+
+	b.Run("ephemeral_delta_plus_shared_template", func(b *testing.B) {
+		registry := keeper.NewInMemoryEphemeralRegistry()
+		templateID, err := registry.Register(pubKeyAny, "ibc")
+		require.NoError(b, err)
+
+		total := len(cdc.MustMarshal(pubKeyAny)) // the one shared template, stored once
+		for i := 0; i < numClients; i++ {
+			delta := solomachine.ClientStateDelta{Sequence: 42, Timestamp: 10, TemplateID: templateID}
+			total += len(cdc.MustMarshal(&delta))
+		}
+		b.ReportMetric(float64(total)/numClients, "bytes/client")
+	})
+}
@@ -0,0 +1,16 @@
+package solomachine
+
+import (
+	errorsmod "cosmossdk.io/errors"
+)
+
+// Solo machine sentinel errors
+var (
+	ErrInvalidSequence             = errorsmod.Register(ModuleName, 2, "sequence number is invalid")
+	ErrInvalidPubKey               = errorsmod.Register(ModuleName, 3, "public key is invalid")
+	ErrInvalidSignatureAndData     = errorsmod.Register(ModuleName, 4, "signature and data bytes are invalid")
+	ErrSignatureVerificationFailed = errorsmod.Register(ModuleName, 5, "signature verification failed")
+	ErrInvalidClientMessage        = errorsmod.Register(ModuleName, 6, "invalid client message")
+	ErrInvalidHeader               = errorsmod.Register(ModuleName, 7, "invalid header")
+	ErrInvalidHeaderBatch          = errorsmod.Register(ModuleName, 8, "invalid header batch")
+)
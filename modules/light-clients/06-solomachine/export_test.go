@@ -0,0 +1,87 @@
+package solomachine
+
+import (
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
+	"github.com/cosmos/ibc-go/v8/modules/core/exported"
+	"github.com/cosmos/ibc-go/v8/modules/light-clients/06-solomachine/keeper"
+)
+
+// This file exposes unexported identifiers needed by _test.go files in the solomachine_test
+// package, following the standard Go "export_test.go" pattern.
+
+// VerifyHeaderBatchForTest exposes ClientState.verifyHeaderBatch for tests.
+func VerifyHeaderBatchForTest(ctx sdk.Context, cs ClientState, batch HeaderBatch) error {
+	return cs.verifyHeaderBatch(ctx, batch)
+}
+
+// UpdateStateFromHeaderBatchForTest exposes ClientState.updateStateFromHeaderBatch for tests.
+func UpdateStateFromHeaderBatchForTest(cs ClientState, batch HeaderBatch) (ClientState, []exported.Height) {
+	return cs.updateStateFromHeaderBatch(batch)
+}
+
+// HeaderSignBytesDataForTest exposes headerSignBytesData for tests.
+func HeaderSignBytesDataForTest(newPublicKey *codectypes.Any, newDiversifier string) ([]byte, error) {
+	return headerSignBytesData(newPublicKey, newDiversifier)
+}
+
+// SignBytesForTest builds the same SignBytes encoding used internally by verifyProof, so tests
+// can produce signatures a real solo machine signing client would.
+func SignBytesForTest(sequence, timestamp uint64, diversifier, path string, data []byte) ([]byte, error) {
+	sb := SignBytes{
+		Sequence:    sequence,
+		Timestamp:   timestamp,
+		Diversifier: diversifier,
+		Path:        []byte(path),
+		Data:        data,
+	}
+
+	return ModuleCdc.MarshalJSON(&sb)
+}
+
+// ClientStateKeyForTest exposes the standard host.ClientStateKey() used for the legacy (non
+// ephemeral) client state storage layout.
+func ClientStateKeyForTest() []byte {
+	return host.ClientStateKey()
+}
+
+// LoadClientStateForTest exposes loadClientState for tests.
+func LoadClientStateForTest(cdc codec.BinaryCodec, registry keeper.EphemeralRegistry, store storetypes.KVStore, clientID string) (ClientState, error) {
+	return loadClientState(cdc, registry, store, clientID)
+}
+
+// StoreEphemeralClientStateForTest exposes storeEphemeralClientState for tests.
+func StoreEphemeralClientStateForTest(cdc codec.BinaryCodec, registry keeper.EphemeralRegistry, store storetypes.KVStore, cs ClientState) error {
+	return storeEphemeralClientState(cdc, registry, store, cs)
+}
+
+// UpdatePathForTest exposes the updatePath sentinel path type for tests.
+func UpdatePathForTest(path string) exported.Path {
+	return updatePath(path)
+}
+
+// VerifyMembershipForTest exposes ClientState.VerifyMembership for tests without requiring a
+// real store/codec/height, none of which VerifyMembership uses.
+func VerifyMembershipForTest(ctx sdk.Context, cs ClientState, path exported.Path, value, proof []byte) error {
+	return cs.VerifyMembership(ctx, nil, nil, nil, 0, 0, proof, path, value)
+}
+
+// VerifyHeaderForTest exposes ClientState.verifyHeader for tests.
+func VerifyHeaderForTest(ctx sdk.Context, cs ClientState, header Header) error {
+	return cs.verifyHeader(ctx, nil, header)
+}
+
+// VerifyMisbehaviourForTest exposes ClientState.verifyMisbehaviour for tests.
+func VerifyMisbehaviourForTest(ctx sdk.Context, cs ClientState, misbehaviour Misbehaviour) error {
+	return cs.verifyMisbehaviour(ctx, nil, misbehaviour)
+}
+
+// MisbehaviourPathForTest exposes the misbehaviourPath sentinel path for tests.
+func MisbehaviourPathForTest() exported.Path {
+	return misbehaviourPath
+}
@@ -0,0 +1,155 @@
+package solomachine
+
+import (
+	"strings"
+
+	errorsmod "cosmossdk.io/errors"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+
+	"github.com/cosmos/ibc-go/v8/modules/core/exported"
+)
+
+var (
+	_ exported.ClientMessage             = (*Header)(nil)
+	_ codectypes.UnpackInterfacesMessage = (*Header)(nil)
+)
+
+// Header defines a solo machine consensus header. Submitting a Header to MsgUpdateClient rotates
+// the solo machine's public key and/or diversifier in place, without changing the sequence other
+// than incrementing it by one.
+type Header struct {
+	Sequence       uint64          `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence"`
+	Timestamp      uint64          `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp"`
+	Signature      []byte          `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature"`
+	NewPublicKey   *codectypes.Any `protobuf:"bytes,4,opt,name=new_public_key,json=newPublicKey,proto3" json:"new_public_key"`
+	NewDiversifier string          `protobuf:"bytes,5,opt,name=new_diversifier,json=newDiversifier,proto3" json:"new_diversifier"`
+}
+
+// UnpackInterfaces implements codectypes.UnpackInterfacesMessage, resolving NewPublicKey's
+// concrete type (a cryptotypes.PubKey, or a ThresholdPublicKey committee) once this Header has
+// been unmarshalled through a codec whose InterfaceRegistry has RegisterInterfaces applied.
+func (h Header) UnpackInterfaces(unpacker codectypes.AnyUnpacker) error {
+	var pubKey cryptotypes.PubKey
+	return unpacker.UnpackAny(h.NewPublicKey, &pubKey)
+}
+
+// Marshal implements the gogoproto Marshaler interface. See wire.go.
+func (h *Header) Marshal() ([]byte, error) {
+	if h == nil {
+		return nil, nil
+	}
+
+	var dst []byte
+	if h.Sequence != 0 {
+		dst = appendVarintField(dst, 1, h.Sequence)
+	}
+	if h.Timestamp != 0 {
+		dst = appendVarintField(dst, 2, h.Timestamp)
+	}
+	if len(h.Signature) != 0 {
+		dst = appendBytesField(dst, 3, h.Signature)
+	}
+	if h.NewPublicKey != nil {
+		bz, err := h.NewPublicKey.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		dst = appendBytesField(dst, 4, bz)
+	}
+	if h.NewDiversifier != "" {
+		dst = appendStringField(dst, 5, h.NewDiversifier)
+	}
+
+	return dst, nil
+}
+
+// Size implements the gogoproto Sizer interface. See wire.go.
+func (h *Header) Size() int {
+	if h == nil {
+		return 0
+	}
+
+	var n int
+	if h.Sequence != 0 {
+		n += sizeVarintField(1, h.Sequence)
+	}
+	if h.Timestamp != 0 {
+		n += sizeVarintField(2, h.Timestamp)
+	}
+	if len(h.Signature) != 0 {
+		n += sizeBytesField(3, h.Signature)
+	}
+	if h.NewPublicKey != nil {
+		n += sizeEmbedded(4, h.NewPublicKey.Size())
+	}
+	if h.NewDiversifier != "" {
+		n += sizeStringField(5, h.NewDiversifier)
+	}
+
+	return n
+}
+
+// Unmarshal implements the gogoproto Unmarshaler interface. See wire.go.
+func (h *Header) Unmarshal(bz []byte) error {
+	*h = Header{}
+
+	for len(bz) > 0 {
+		f, rest, err := nextField(bz)
+		if err != nil {
+			return err
+		}
+		bz = rest
+
+		switch f.num {
+		case 1:
+			h.Sequence = f.varint
+		case 2:
+			h.Timestamp = f.varint
+		case 3:
+			h.Signature = append([]byte(nil), f.bytes...)
+		case 4:
+			var any codectypes.Any
+			if err := any.Unmarshal(f.bytes); err != nil {
+				return err
+			}
+			h.NewPublicKey = &any
+		case 5:
+			h.NewDiversifier = string(f.bytes)
+		}
+	}
+
+	return nil
+}
+
+// ClientType defines that the Header is a Solo Machine consensus algorithm.
+func (Header) ClientType() string {
+	return exported.Solomachine
+}
+
+// ValidateBasic ensures that the sequence, timestamp, signature, and new public key are all
+// non-empty/non-zero and that the new diversifier is not a blank string.
+func (h Header) ValidateBasic() error {
+	if h.Sequence == 0 {
+		return errorsmod.Wrap(ErrInvalidHeader, "sequence cannot be 0")
+	}
+
+	if h.Timestamp == 0 {
+		return errorsmod.Wrap(ErrInvalidHeader, "timestamp cannot be 0")
+	}
+
+	if len(h.Signature) == 0 {
+		return errorsmod.Wrap(ErrInvalidHeader, "signature cannot be empty")
+	}
+
+	if h.NewPublicKey == nil || h.NewPublicKey.GetCachedValue() == nil {
+		return errorsmod.Wrap(ErrInvalidHeader, "new public key cannot be nil")
+	}
+
+	if h.NewDiversifier != "" && strings.TrimSpace(h.NewDiversifier) == "" {
+		return errorsmod.Wrap(ErrInvalidHeader, "new diversifier cannot contain only spaces")
+	}
+
+	return nil
+}
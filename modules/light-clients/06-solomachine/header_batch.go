@@ -0,0 +1,256 @@
+package solomachine
+
+import (
+	errorsmod "cosmossdk.io/errors"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	"github.com/cosmos/ibc-go/v8/modules/core/exported"
+)
+
+var (
+	_ exported.ClientMessage             = (*HeaderBatch)(nil)
+	_ codectypes.UnpackInterfacesMessage = (*HeaderBatch)(nil)
+)
+
+// HeaderBatch defines an ordered sequence of solo machine Headers intended to be applied in a
+// single MsgUpdateClient. Each entry's signature is expected to chain from the previous entry's
+// NewPublicKey/NewDiversifier (the first entry chains from the client's current consensus
+// state), letting relayers amortize gas and catch up a long-offline solo machine in one tx.
+type HeaderBatch struct {
+	Headers []*Header `protobuf:"bytes,1,rep,name=headers,proto3" json:"headers"`
+}
+
+// UnpackInterfaces implements codectypes.UnpackInterfacesMessage, delegating to each Header in
+// the batch to resolve its NewPublicKey.
+func (hb HeaderBatch) UnpackInterfaces(unpacker codectypes.AnyUnpacker) error {
+	for _, h := range hb.Headers {
+		if err := h.UnpackInterfaces(unpacker); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Marshal implements the gogoproto Marshaler interface. See wire.go.
+func (hb *HeaderBatch) Marshal() ([]byte, error) {
+	if hb == nil {
+		return nil, nil
+	}
+
+	var dst []byte
+	for _, h := range hb.Headers {
+		bz, err := h.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		dst = appendBytesField(dst, 1, bz)
+	}
+
+	return dst, nil
+}
+
+// Size implements the gogoproto Sizer interface. See wire.go.
+func (hb *HeaderBatch) Size() int {
+	if hb == nil {
+		return 0
+	}
+
+	var n int
+	for _, h := range hb.Headers {
+		n += sizeEmbedded(1, h.Size())
+	}
+
+	return n
+}
+
+// Unmarshal implements the gogoproto Unmarshaler interface. See wire.go.
+func (hb *HeaderBatch) Unmarshal(bz []byte) error {
+	*hb = HeaderBatch{}
+
+	for len(bz) > 0 {
+		f, rest, err := nextField(bz)
+		if err != nil {
+			return err
+		}
+		bz = rest
+
+		if f.num == 1 {
+			var h Header
+			if err := h.Unmarshal(f.bytes); err != nil {
+				return err
+			}
+			hb.Headers = append(hb.Headers, &h)
+		}
+	}
+
+	return nil
+}
+
+// ClientType defines that the HeaderBatch is a Solo Machine consensus algorithm client message.
+func (HeaderBatch) ClientType() string {
+	return exported.Solomachine
+}
+
+// ValidateBasic ensures the batch is non-empty and that every entry independently passes
+// Header.ValidateBasic. Sequencing (no gaps, no reordering) is enforced by verifyHeaderBatch,
+// since it requires knowledge of the client's current sequence.
+func (hb HeaderBatch) ValidateBasic() error {
+	if len(hb.Headers) == 0 {
+		return errorsmod.Wrap(ErrInvalidHeaderBatch, "header batch cannot be empty")
+	}
+
+	for i, header := range hb.Headers {
+		if header == nil {
+			return errorsmod.Wrapf(ErrInvalidHeaderBatch, "header at index %d is nil", i)
+		}
+
+		if err := header.ValidateBasic(); err != nil {
+			return errorsmod.Wrapf(err, "header at index %d failed basic validation", i)
+		}
+	}
+
+	return nil
+}
+
+// signingCursor tracks the public key material (a single signer or a ThresholdPublicKey),
+// diversifier, and timestamp that the next header in a batch must chain its signature from.
+type signingCursor struct {
+	pubKey      *codectypes.Any
+	diversifier string
+	timestamp   uint64
+}
+
+// verifyHeaderBatch verifies each header in the batch in order. A header must either continue the
+// batch at the next not-yet-advanced sequence, or immediately repeat the sequence of the header
+// just processed - any other sequence is an out-of-order or gap batch and is rejected outright.
+// Timestamps must be non-decreasing, and each header's signature must verify against the public
+// key material/diversifier committed to by the previous header (or, for the first header, by the
+// client's current ConsensusState). A repeated sequence is verified against that *same* prior
+// cursor rather than against the header it repeats, and does not itself advance the cursor: if its
+// signature is valid but its content disagrees with the header it repeats, CheckForMisbehaviour
+// (via headerBatchHasConflict) treats the pair as misbehaviour evidence once verification has
+// succeeded, rather than this method rejecting the batch as a forgery.
+func (cs ClientState) verifyHeaderBatch(ctx sdk.Context, batch HeaderBatch) error {
+	if cs.IsFrozen {
+		return ErrInvalidClientMessage
+	}
+
+	if err := batch.ValidateBasic(); err != nil {
+		return err
+	}
+
+	if cs.ConsensusState == nil {
+		return errorsmod.Wrap(ErrInvalidHeader, "consensus state is nil")
+	}
+
+	cur := signingCursor{
+		pubKey:      cs.ConsensusState.PublicKey,
+		diversifier: cs.ConsensusState.Diversifier,
+		timestamp:   cs.ConsensusState.Timestamp,
+	}
+
+	expected := cs.Sequence
+	cursorAt := make(map[uint64]signingCursor, len(batch.Headers))
+
+	for i, header := range batch.Headers {
+		var priorCursor signingCursor
+
+		switch {
+		case header.Sequence == expected:
+			priorCursor = cur
+			cursorAt[header.Sequence] = cur
+		case expected > cs.Sequence && header.Sequence == expected-1:
+			// repeats the sequence of the header just advanced past; verify against the same
+			// cursor that header was verified against, not against it directly.
+			priorCursor = cursorAt[header.Sequence]
+		default:
+			return errorsmod.Wrapf(ErrInvalidSequence, "header at index %d has sequence %d, expected %d or %d (out-of-order or gap batch)", i, header.Sequence, expected, expected-1)
+		}
+
+		if header.Timestamp < priorCursor.timestamp {
+			return errorsmod.Wrapf(ErrInvalidHeader, "header at index %d has timestamp %d before previous timestamp %d", i, header.Timestamp, priorCursor.timestamp)
+		}
+
+		data, err := headerSignBytesData(header.NewPublicKey, header.NewDiversifier)
+		if err != nil {
+			return err
+		}
+
+		if err := verifyProof(ctx, priorCursor.pubKey, priorCursor.diversifier, header.Sequence, priorCursor.timestamp, headerUpdatePath, data, header.Signature); err != nil {
+			return errorsmod.Wrapf(err, "header at index %d failed signature verification", i)
+		}
+
+		if header.Sequence == expected {
+			cur = signingCursor{pubKey: header.NewPublicKey, diversifier: header.NewDiversifier, timestamp: header.Timestamp}
+			expected++
+		}
+	}
+
+	return nil
+}
+
+// headerBatchHasConflict reports whether two headers in the batch share a sequence number but
+// disagree (differing signatures), which verifyHeaderBatch treats as valid misbehaviour evidence
+// rather than rejecting outright.
+func headerBatchHasConflict(batch HeaderBatch) bool {
+	bySequence := make(map[uint64]*Header, len(batch.Headers))
+
+	for _, header := range batch.Headers {
+		prior, ok := bySequence[header.Sequence]
+		if !ok {
+			bySequence[header.Sequence] = header
+			continue
+		}
+
+		if string(prior.Signature) != string(header.Signature) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// updateStateFromHeaderBatch applies every header in the batch in order, advancing the sequence
+// and rotating the public key/diversifier/timestamp once per *distinct* sequence number the batch
+// actually rotates through - not once per header in the Headers slice. verifyHeaderBatch allows a
+// header to immediately repeat the sequence of the header just processed (verified against that
+// same prior cursor rather than against the header it repeats); such a repeat must not advance
+// the sequence a second time, or the resulting ClientState desyncs from the solo machine's actual
+// signing history. Only the final resulting ConsensusState is reflected in the returned
+// ClientState - intermediate consensus states in a batch are never queried directly, so there is
+// no reason to pay the gas to store them. The full list of (sentinel) consensus heights produced,
+// one per header, is returned so callers can report exactly how much progress the batch made.
+// Persisting the returned ClientState is the caller's responsibility, same as
+// ClientState.UpdateState.
+func (cs ClientState) updateStateFromHeaderBatch(batch HeaderBatch) (ClientState, []exported.Height) {
+	heights := make([]exported.Height, len(batch.Headers))
+
+	applied := false
+	lastSequence := cs.Sequence
+
+	for i, header := range batch.Headers {
+		if applied && header.Sequence == lastSequence {
+			// repeats the sequence just applied; already verified against the same prior cursor
+			// by verifyHeaderBatch and does not itself advance the sequence.
+			heights[i] = clienttypes.ZeroHeight()
+			continue
+		}
+
+		cs.Sequence++
+		cs.ConsensusState = &ConsensusState{
+			PublicKey:   header.NewPublicKey,
+			Diversifier: header.NewDiversifier,
+			Timestamp:   header.Timestamp,
+		}
+		lastSequence = header.Sequence
+		applied = true
+
+		heights[i] = clienttypes.ZeroHeight()
+	}
+
+	return cs, heights
+}
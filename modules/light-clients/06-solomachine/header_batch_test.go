@@ -0,0 +1,158 @@
+package solomachine_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	solomachine "github.com/cosmos/ibc-go/v8/modules/light-clients/06-solomachine"
+)
+
+// buildChainedBatch builds a HeaderBatch of n headers, each rotating to a fresh key, chained
+// starting from initial{pubKey,diversifier,timestamp,sequence}. It returns the batch together
+// with the ClientState that it should successfully update against.
+func buildChainedBatch(t *testing.T, n int, startSequence uint64) (solomachine.ClientState, solomachine.HeaderBatch) {
+	t.Helper()
+
+	privKey := secp256k1.GenPrivKey()
+	pubKeyAny, err := codectypes.NewAnyWithValue(privKey.PubKey())
+	require.NoError(t, err)
+
+	clientState := solomachine.ClientState{
+		Sequence: startSequence,
+		ConsensusState: &solomachine.ConsensusState{
+			PublicKey:   pubKeyAny,
+			Diversifier: "ibc",
+			Timestamp:   10,
+		},
+	}
+
+	curKey := privKey
+	curDiversifier := "ibc"
+	curTimestamp := uint64(10)
+
+	headers := make([]*solomachine.Header, n)
+	for i := 0; i < n; i++ {
+		newKey := secp256k1.GenPrivKey()
+		newPubKeyAny, err := codectypes.NewAnyWithValue(newKey.PubKey())
+		require.NoError(t, err)
+
+		newTimestamp := curTimestamp + 1
+		sequence := startSequence + uint64(i)
+
+		header := &solomachine.Header{
+			Sequence:       sequence,
+			Timestamp:      newTimestamp,
+			NewPublicKey:   newPubKeyAny,
+			NewDiversifier: curDiversifier,
+		}
+
+		header.Signature = signHeader(t, curKey, curDiversifier, sequence, curTimestamp, newPubKeyAny, curDiversifier)
+
+		headers[i] = header
+
+		curKey = newKey
+		curTimestamp = newTimestamp
+	}
+
+	return clientState, solomachine.HeaderBatch{Headers: headers}
+}
+
+// signHeader produces the signature a real solo machine signing client would produce for a
+// header rotating to newPublicKey/newDiversifier, signed by privKey at the given
+// sequence/diversifier/timestamp.
+func signHeader(t *testing.T, privKey cryptotypes.PrivKey, diversifier string, sequence, timestamp uint64, newPublicKey *codectypes.Any, newDiversifier string) []byte {
+	t.Helper()
+
+	data, err := solomachine.HeaderSignBytesDataForTest(newPublicKey, newDiversifier)
+	require.NoError(t, err)
+
+	bz, err := solomachine.SignBytesForTest(sequence, timestamp, diversifier, "update", data)
+	require.NoError(t, err)
+
+	sig, err := privKey.Sign(bz)
+	require.NoError(t, err)
+
+	return sig
+}
+
+func TestVerifyHeaderBatch_Valid(t *testing.T) {
+	clientState, batch := buildChainedBatch(t, 5, 1)
+
+	err := solomachine.VerifyHeaderBatchForTest(sdk.Context{}, clientState, batch)
+	require.NoError(t, err)
+}
+
+func TestVerifyHeaderBatch_RejectsGap(t *testing.T) {
+	clientState, batch := buildChainedBatch(t, 5, 1)
+
+	batch.Headers[2].Sequence++ // introduce a gap
+
+	err := solomachine.VerifyHeaderBatchForTest(sdk.Context{}, clientState, batch)
+	require.ErrorIs(t, err, solomachine.ErrInvalidSequence)
+}
+
+func TestVerifyHeaderBatch_RejectsOutOfOrder(t *testing.T) {
+	clientState, batch := buildChainedBatch(t, 5, 1)
+
+	batch.Headers[1], batch.Headers[2] = batch.Headers[2], batch.Headers[1]
+
+	err := solomachine.VerifyHeaderBatchForTest(sdk.Context{}, clientState, batch)
+	require.Error(t, err)
+}
+
+func TestUpdateStateFromHeaderBatch_DuplicateHeaderDoesNotDoubleAdvanceSequence(t *testing.T) {
+	clientState, batch := buildChainedBatch(t, 3, 1)
+
+	// repeat the last header verbatim, as verifyHeaderBatch allows for a header that repeats the
+	// sequence just advanced past.
+	last := batch.Headers[len(batch.Headers)-1]
+	repeated := *last
+	batchWithRepeat := solomachine.HeaderBatch{Headers: append(append([]*solomachine.Header(nil), batch.Headers...), &repeated)}
+
+	updated, heights := solomachine.UpdateStateFromHeaderBatchForTest(clientState, batch)
+	updatedWithRepeat, heightsWithRepeat := solomachine.UpdateStateFromHeaderBatchForTest(clientState, batchWithRepeat)
+
+	require.Equal(t, updated.Sequence, updatedWithRepeat.Sequence)
+	require.Equal(t, updated.ConsensusState, updatedWithRepeat.ConsensusState)
+	require.Len(t, heightsWithRepeat, len(heights)+1)
+}
+
+// FuzzVerifyHeaderBatchPermutations asserts that a correctly-chained batch verifies, and that any
+// random permutation of it (other than the identity permutation) is rejected, since a permutation
+// necessarily introduces either a sequence gap or an out-of-order entry.
+func FuzzVerifyHeaderBatchPermutations(f *testing.F) {
+	f.Add(int64(1))
+	f.Add(int64(42))
+	f.Add(int64(1000))
+
+	f.Fuzz(func(t *testing.T, seed int64) {
+		clientState, batch := buildChainedBatch(t, 6, 1)
+
+		require.NoError(t, solomachine.VerifyHeaderBatchForTest(sdk.Context{}, clientState, batch))
+
+		r := rand.New(rand.NewSource(seed))
+		shuffled := append([]*solomachine.Header(nil), batch.Headers...)
+		r.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+
+		identity := true
+		for i := range shuffled {
+			if shuffled[i] != batch.Headers[i] {
+				identity = false
+				break
+			}
+		}
+		if identity {
+			return
+		}
+
+		err := solomachine.VerifyHeaderBatchForTest(sdk.Context{}, clientState, solomachine.HeaderBatch{Headers: shuffled})
+		require.Error(t, err)
+	})
+}
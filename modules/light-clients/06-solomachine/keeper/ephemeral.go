@@ -0,0 +1,21 @@
+package keeper
+
+import (
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+)
+
+// EphemeralRegistry stores the public key and diversifier that an ephemeral solo machine client
+// currently signs with (the ones it was created with, or any it has since rotated to), keyed by an
+// opaque template ID. Chains that provision many solo
+// machine clients sharing an initial public key (e.g. a custody system issuing many accounts from
+// one signing key) can register it once and have every such client reference the same template
+// instead of each storing its own copy. A Keeper with no EphemeralRegistry configured (the
+// default) never enters ephemeral mode; clients are stored as a full ClientState as before.
+type EphemeralRegistry interface {
+	// Register stores (or looks up an existing) template for the given public key and
+	// diversifier, returning its template ID.
+	Register(pubKey *codectypes.Any, diversifier string) (templateID string, err error)
+
+	// Resolve returns the public key and diversifier for a previously registered template ID.
+	Resolve(templateID string) (pubKey *codectypes.Any, diversifier string, found bool)
+}
@@ -0,0 +1,170 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"sync"
+
+	storetypes "cosmossdk.io/store/types"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+)
+
+// InMemoryEphemeralRegistry is a simple EphemeralRegistry keyed by the sha256 hash of the public
+// key bytes and diversifier, so that clients created with identical initial key material always
+// resolve to the same template ID regardless of registration order. It is suitable for
+// single-node testing and tooling, but its process-local map is not backed by the chain's IAVL
+// store: it is not part of consensus state, does not survive a process restart, and will not
+// agree across validators. Chains running ephemeral mode in production must use
+// KVStoreEphemeralRegistry instead.
+type InMemoryEphemeralRegistry struct {
+	mu        sync.RWMutex
+	templates map[string]template
+}
+
+type template struct {
+	pubKey      *codectypes.Any
+	diversifier string
+}
+
+var _ EphemeralRegistry = (*InMemoryEphemeralRegistry)(nil)
+
+// NewInMemoryEphemeralRegistry returns an empty InMemoryEphemeralRegistry.
+func NewInMemoryEphemeralRegistry() *InMemoryEphemeralRegistry {
+	return &InMemoryEphemeralRegistry{templates: make(map[string]template)}
+}
+
+// Register implements EphemeralRegistry.
+func (r *InMemoryEphemeralRegistry) Register(pubKey *codectypes.Any, diversifier string) (string, error) {
+	templateID := templateIDFor(pubKey, diversifier)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.templates[templateID]; !ok {
+		r.templates[templateID] = template{pubKey: pubKey, diversifier: diversifier}
+	}
+
+	return templateID, nil
+}
+
+// Resolve implements EphemeralRegistry.
+func (r *InMemoryEphemeralRegistry) Resolve(templateID string) (*codectypes.Any, string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	t, ok := r.templates[templateID]
+	if !ok {
+		return nil, "", false
+	}
+
+	return t.pubKey, t.diversifier, true
+}
+
+func templateIDFor(pubKey *codectypes.Any, diversifier string) string {
+	h := sha256.New()
+	if pubKey != nil {
+		h.Write(pubKey.Value)
+		h.Write([]byte(pubKey.TypeUrl))
+	}
+	h.Write([]byte(diversifier))
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// templateStoreKeyPrefix namespaces KVStoreEphemeralRegistry's keys within whatever store it is
+// given, in case that store is ever shared with other data.
+var templateStoreKeyPrefix = []byte("solomachine/ephemeral/template/")
+
+// KVStoreEphemeralRegistry is an EphemeralRegistry backed by a storetypes.KVStore, so that
+// registered templates are part of the chain's IAVL-backed consensus state rather than a single
+// node's in-process memory - the same requirement every other piece of durable IBC client state
+// must meet. Like InMemoryEphemeralRegistry, it is keyed by the sha256 hash of the public key
+// bytes and diversifier, so registration is idempotent and content-addressed.
+type KVStoreEphemeralRegistry struct {
+	store storetypes.KVStore
+}
+
+var _ EphemeralRegistry = (*KVStoreEphemeralRegistry)(nil)
+
+// NewKVStoreEphemeralRegistry returns a KVStoreEphemeralRegistry backed by store. Callers
+// typically supply a store scoped to the whole module (not a single client's prefixed store),
+// since templates are meant to be shared across every client that registers matching key
+// material.
+func NewKVStoreEphemeralRegistry(store storetypes.KVStore) *KVStoreEphemeralRegistry {
+	return &KVStoreEphemeralRegistry{store: store}
+}
+
+// Register implements EphemeralRegistry.
+func (r *KVStoreEphemeralRegistry) Register(pubKey *codectypes.Any, diversifier string) (string, error) {
+	templateID := templateIDFor(pubKey, diversifier)
+
+	key := templateStoreKey(templateID)
+	if !r.store.Has(key) {
+		bz, err := encodeTemplate(pubKey, diversifier)
+		if err != nil {
+			return "", err
+		}
+		r.store.Set(key, bz)
+	}
+
+	return templateID, nil
+}
+
+// Resolve implements EphemeralRegistry.
+func (r *KVStoreEphemeralRegistry) Resolve(templateID string) (*codectypes.Any, string, bool) {
+	bz := r.store.Get(templateStoreKey(templateID))
+	if len(bz) == 0 {
+		return nil, "", false
+	}
+
+	pubKey, diversifier, err := decodeTemplate(bz)
+	if err != nil {
+		return nil, "", false
+	}
+
+	return pubKey, diversifier, true
+}
+
+func templateStoreKey(templateID string) []byte {
+	return append(append([]byte(nil), templateStoreKeyPrefix...), templateID...)
+}
+
+// encodeTemplate serializes pubKey and diversifier as a 4-byte big-endian length followed by
+// pubKey's own real Any.Marshal() bytes, followed by the raw diversifier bytes. This is a private
+// storage encoding, not part of the wire protocol - reusing codectypes.Any's existing Marshal
+// rather than inventing a redundant encoding for it.
+func encodeTemplate(pubKey *codectypes.Any, diversifier string) ([]byte, error) {
+	pubKeyBz, err := pubKey.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 4+len(pubKeyBz)+len(diversifier))
+	binary.BigEndian.PutUint32(buf, uint32(len(pubKeyBz)))
+	copy(buf[4:], pubKeyBz)
+	copy(buf[4+len(pubKeyBz):], diversifier)
+
+	return buf, nil
+}
+
+// decodeTemplate reverses encodeTemplate.
+func decodeTemplate(bz []byte) (*codectypes.Any, string, error) {
+	if len(bz) < 4 {
+		return nil, "", errors.New("ephemeral template record is truncated")
+	}
+
+	pubKeyLen := binary.BigEndian.Uint32(bz)
+	bz = bz[4:]
+	if uint32(len(bz)) < pubKeyLen {
+		return nil, "", errors.New("ephemeral template record is truncated")
+	}
+
+	var pubKey codectypes.Any
+	if err := pubKey.Unmarshal(bz[:pubKeyLen]); err != nil {
+		return nil, "", err
+	}
+
+	return &pubKey, string(bz[pubKeyLen:]), nil
+}
@@ -0,0 +1,82 @@
+package keeper
+
+import (
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+)
+
+// Keeper defines the 06-solomachine light client module's keeper. It only needs access to the
+// codec used to (un)marshal client and consensus states, the StoreProvider used to reach into a
+// client's prefixed KVStore, an optional SelfValidator used to apply chain-specific policy to
+// newly created clients, and an optional EphemeralRegistry for ephemeral client storage.
+type Keeper struct {
+	cdc           codec.BinaryCodec
+	storeProvider clienttypes.StoreProvider
+
+	selfValidator     SelfValidator
+	ephemeralRegistry EphemeralRegistry
+}
+
+// Option configures a Keeper at construction time.
+type Option func(*Keeper)
+
+// WithSelfValidator overrides the Keeper's SelfValidator. Chains that want to enforce policy on
+// solo machine client creation (e.g. a minimum sequence, an allowed diversifier format, or a
+// denylist of known-compromised public keys) should supply one when wiring up the module.
+func WithSelfValidator(selfValidator SelfValidator) Option {
+	return func(k *Keeper) {
+		k.selfValidator = selfValidator
+	}
+}
+
+// WithEphemeralRegistry opts the keeper into ephemeral client storage: new solo machine clients
+// persist only a small ClientStateDelta (sequence, frozen flag, timestamp, and template ID) and no
+// ConsensusState of their own; the public key and diversifier are resolved from registry on load. A Keeper with no EphemeralRegistry (the default) always
+// stores the full ClientState, matching the module's pre-existing behaviour.
+func WithEphemeralRegistry(registry EphemeralRegistry) Option {
+	return func(k *Keeper) {
+		k.ephemeralRegistry = registry
+	}
+}
+
+// NewKeeper creates a new solomachine Keeper. By default the Keeper is configured with a
+// NoOpSelfValidator, preserving the module's behaviour prior to the introduction of SelfValidator;
+// pass WithSelfValidator to override it.
+func NewKeeper(cdc codec.BinaryCodec, storeProvider clienttypes.StoreProvider, opts ...Option) Keeper {
+	keeper := Keeper{
+		cdc:           cdc,
+		storeProvider: storeProvider,
+		selfValidator: NoOpSelfValidator{},
+	}
+
+	for _, opt := range opts {
+		opt(&keeper)
+	}
+
+	return keeper
+}
+
+// Codec returns the codec used by the keeper.
+func (k Keeper) Codec() codec.BinaryCodec {
+	return k.cdc
+}
+
+// ClientStore returns the client prefixed store for the given clientID.
+func (k Keeper) ClientStore(ctx sdk.Context, clientID string) storetypes.KVStore {
+	return k.storeProvider.ClientStore(ctx, clientID)
+}
+
+// SelfValidator returns the keeper's configured SelfValidator.
+func (k Keeper) SelfValidator() SelfValidator {
+	return k.selfValidator
+}
+
+// EphemeralRegistry returns the keeper's configured EphemeralRegistry, or nil if ephemeral client
+// storage has not been opted into.
+func (k Keeper) EphemeralRegistry() EphemeralRegistry {
+	return k.ephemeralRegistry
+}
@@ -0,0 +1,25 @@
+package keeper
+
+import (
+	"github.com/cosmos/ibc-go/v8/modules/core/exported"
+)
+
+// SelfValidator is consulted by LightClientModule.Initialize before a new solo machine client is
+// accepted onto the chain. It receives the already unmarshalled and ValidateBasic-checked
+// ClientState and ConsensusState, and lets chains layer additional policy on top of the module's
+// baseline validation, such as a minimum sequence, an allowed diversifier format, a required
+// public key scheme (e.g. secp256k1, ed25519, multisig), or a denylist of known-compromised keys.
+type SelfValidator interface {
+	ValidateSelfClient(clientState exported.ClientState, consensusState exported.ConsensusState) error
+}
+
+// NoOpSelfValidator is the default SelfValidator wired up by NewKeeper. It accepts every solo
+// machine client, preserving the module's behaviour for chains that do not configure one.
+type NoOpSelfValidator struct{}
+
+var _ SelfValidator = NoOpSelfValidator{}
+
+// ValidateSelfClient implements SelfValidator.
+func (NoOpSelfValidator) ValidateSelfClient(exported.ClientState, exported.ConsensusState) error {
+	return nil
+}
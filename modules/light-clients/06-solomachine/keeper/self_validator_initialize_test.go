@@ -0,0 +1,125 @@
+package keeper_test
+
+import (
+	"io"
+	"testing"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	solomachine "github.com/cosmos/ibc-go/v8/modules/light-clients/06-solomachine"
+	"github.com/cosmos/ibc-go/v8/modules/light-clients/06-solomachine/keeper"
+)
+
+// memStore is a minimal in-memory storetypes.KVStore, mirroring the fake the solomachine
+// package's own ephemeral_test.go uses, sufficient to drive LightClientModule.Initialize without
+// a full chain/app test harness.
+type memStore struct {
+	data map[string][]byte
+}
+
+func newMemStore() *memStore { return &memStore{data: make(map[string][]byte)} }
+
+func (s *memStore) Get(key []byte) []byte { return s.data[string(key)] }
+func (s *memStore) Has(key []byte) bool   { _, ok := s.data[string(key)]; return ok }
+func (s *memStore) Set(key, value []byte) { s.data[string(key)] = value }
+func (s *memStore) Delete(key []byte)     { delete(s.data, string(key)) }
+func (s *memStore) Iterator(start, end []byte) storetypes.Iterator {
+	panic("not implemented")
+}
+
+func (s *memStore) ReverseIterator(start, end []byte) storetypes.Iterator {
+	panic("not implemented")
+}
+func (s *memStore) GetStoreType() storetypes.StoreType { return storetypes.StoreTypeIAVL }
+func (s *memStore) CacheWrap() storetypes.CacheWrap    { panic("not implemented") }
+func (s *memStore) CacheWrapWithTrace(w io.Writer, tc storetypes.TraceContext) storetypes.CacheWrap {
+	panic("not implemented")
+}
+
+// singleStoreProvider is a clienttypes.StoreProvider that always returns the same store
+// regardless of clientID, sufficient for driving a single client through LightClientModule.
+type singleStoreProvider struct {
+	store storetypes.KVStore
+}
+
+func (p singleStoreProvider) ClientStore(_ sdk.Context, _ string) storetypes.KVStore {
+	return p.store
+}
+
+func newInitializeTestCodec() codec.BinaryCodec {
+	registry := codectypes.NewInterfaceRegistry()
+	solomachine.RegisterInterfaces(registry)
+
+	return codec.NewProtoCodec(registry)
+}
+
+// TestLightClientModule_Initialize_AllowedKeySelfValidator drives a real LightClientModule,
+// configured with allowedKeySelfValidator, through Initialize against a genuine
+// *solomachine.ConsensusState unmarshalled the same way the keeper would unmarshal one from a
+// MsgCreateClient - unlike fakeConsensusState, which never exercises ConsensusState.GetPubKey's
+// real implementation.
+func TestLightClientModule_Initialize_AllowedKeySelfValidator(t *testing.T) {
+	cdc := newInitializeTestCodec()
+	store := newMemStore()
+
+	k := keeper.NewKeeper(cdc, singleStoreProvider{store: store}, keeper.WithSelfValidator(allowedKeySelfValidator{}))
+	module := solomachine.NewLightClientModule(k)
+
+	privKey := secp256k1.GenPrivKey()
+	pubKeyAny, err := codectypes.NewAnyWithValue(privKey.PubKey())
+	require.NoError(t, err)
+
+	consensusState := &solomachine.ConsensusState{
+		PublicKey:   pubKeyAny,
+		Diversifier: "ibc-1",
+		Timestamp:   10,
+	}
+	clientState := &solomachine.ClientState{
+		Sequence:       1,
+		ConsensusState: consensusState,
+	}
+
+	err = module.Initialize(sdk.Context{}, "06-solomachine-0", cdc.MustMarshal(clientState), cdc.MustMarshal(consensusState))
+	require.NoError(t, err)
+}
+
+// TestLightClientModule_Initialize_AllowedKeySelfValidator_RejectsThresholdPubKey asserts that
+// allowedKeySelfValidator rejects a real ThresholdPublicKey committee the same way it rejects any
+// other unsupported public key type, driven through the real Initialize -> SelfValidator ->
+// ConsensusState.GetPubKey() path rather than fakeConsensusState's hand-rolled stand-in.
+func TestLightClientModule_Initialize_AllowedKeySelfValidator_RejectsThresholdPubKey(t *testing.T) {
+	cdc := newInitializeTestCodec()
+	store := newMemStore()
+
+	k := keeper.NewKeeper(cdc, singleStoreProvider{store: store}, keeper.WithSelfValidator(allowedKeySelfValidator{}))
+	module := solomachine.NewLightClientModule(k)
+
+	participantAny, err := codectypes.NewAnyWithValue(secp256k1.GenPrivKey().PubKey())
+	require.NoError(t, err)
+
+	tpkAny, err := codectypes.NewAnyWithValue(&solomachine.ThresholdPublicKey{
+		Threshold:    1,
+		Participants: []*codectypes.Any{participantAny},
+	})
+	require.NoError(t, err)
+
+	consensusState := &solomachine.ConsensusState{
+		PublicKey:   tpkAny,
+		Diversifier: "ibc-1",
+		Timestamp:   10,
+	}
+	clientState := &solomachine.ClientState{
+		Sequence:       1,
+		ConsensusState: consensusState,
+	}
+
+	err = module.Initialize(sdk.Context{}, "06-solomachine-0", cdc.MustMarshal(clientState), cdc.MustMarshal(consensusState))
+	require.Error(t, err)
+}
@@ -0,0 +1,100 @@
+package keeper_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+
+	"github.com/cosmos/ibc-go/v8/modules/core/exported"
+	"github.com/cosmos/ibc-go/v8/modules/light-clients/06-solomachine/keeper"
+)
+
+var diversifierRegexp = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// diversifiedConsensusState is the subset of the solomachine ConsensusState that
+// allowedKeySelfValidator needs; it is expressed as an interface so that the validator accepts
+// both the real *solomachine.ConsensusState and fakeConsensusState below.
+type diversifiedConsensusState interface {
+	exported.ConsensusState
+	GetDiversifier() string
+	GetPubKey() (cryptotypes.PubKey, error)
+}
+
+// allowedKeySelfValidator rejects any ConsensusState whose diversifier does not match
+// diversifierRegexp, or whose public key is neither secp256k1 nor ed25519.
+type allowedKeySelfValidator struct{}
+
+func (allowedKeySelfValidator) ValidateSelfClient(clientState exported.ClientState, consensusState exported.ConsensusState) error {
+	cs, ok := consensusState.(diversifiedConsensusState)
+	if !ok {
+		return fmt.Errorf("unexpected consensus state type %T", consensusState)
+	}
+
+	if !diversifierRegexp.MatchString(cs.GetDiversifier()) {
+		return fmt.Errorf("diversifier %q is not allowed", cs.GetDiversifier())
+	}
+
+	pubKey, err := cs.GetPubKey()
+	if err != nil {
+		return fmt.Errorf("unsupported public key: %w", err)
+	}
+
+	switch pubKey.(type) {
+	case *secp256k1.PubKey, *ed25519.PubKey:
+		return nil
+	default:
+		return fmt.Errorf("unsupported public key type %T", pubKey)
+	}
+}
+
+type fakeConsensusState struct {
+	diversifiedConsensusState
+	diversifier string
+	pubKey      cryptotypes.PubKey
+}
+
+func (f fakeConsensusState) GetDiversifier() string { return f.diversifier }
+func (f fakeConsensusState) GetPubKey() (cryptotypes.PubKey, error) {
+	if f.pubKey == nil {
+		return nil, fmt.Errorf("consensus state public key is nil")
+	}
+
+	return f.pubKey, nil
+}
+
+func TestNewKeeper_DefaultsToNoOpSelfValidator(t *testing.T) {
+	k := keeper.NewKeeper(nil, nil)
+
+	require.IsType(t, keeper.NoOpSelfValidator{}, k.SelfValidator())
+	require.NoError(t, k.SelfValidator().ValidateSelfClient(nil, nil))
+}
+
+func TestWithSelfValidator_Overrides(t *testing.T) {
+	k := keeper.NewKeeper(nil, nil, keeper.WithSelfValidator(allowedKeySelfValidator{}))
+
+	require.IsType(t, allowedKeySelfValidator{}, k.SelfValidator())
+}
+
+func TestAllowedKeySelfValidator_RejectsDisallowedDiversifier(t *testing.T) {
+	validator := allowedKeySelfValidator{}
+
+	cs := fakeConsensusState{diversifier: "Not Allowed!", pubKey: secp256k1.GenPrivKey().PubKey()}
+	err := validator.ValidateSelfClient(nil, cs)
+	require.Error(t, err)
+}
+
+func TestAllowedKeySelfValidator_RejectsUnsupportedPubKeyType(t *testing.T) {
+	validator := allowedKeySelfValidator{}
+
+	multisigPubKey := multisig.NewLegacyAminoPubKey(1, []cryptotypes.PubKey{secp256k1.GenPrivKey().PubKey()})
+	cs := fakeConsensusState{diversifier: "diversifier-1", pubKey: multisigPubKey}
+	err := validator.ValidateSelfClient(nil, cs)
+	require.Error(t, err)
+}
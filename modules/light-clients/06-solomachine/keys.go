@@ -0,0 +1,6 @@
+package solomachine
+
+const (
+	// ModuleName defines the solo machine light client module name
+	ModuleName = "06-solomachine"
+)
@@ -1,8 +1,6 @@
 package solomachine
 
 import (
-	"fmt"
-
 	errorsmod "cosmossdk.io/errors"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -18,6 +16,11 @@ type LightClientModule struct {
 	keeper keeper.Keeper
 }
 
+// NewLightClientModule creates a new solo machine LightClientModule backed by the given Keeper.
+func NewLightClientModule(keeper keeper.Keeper) LightClientModule {
+	return LightClientModule{keeper: keeper}
+}
+
 // Initialize is called upon client creation, it allows the client to perform validation on the initial consensus state and set the
 // client state, consensus state and any client-specific metadata necessary for correct light client operation in the provided client store.
 func (l LightClientModule) Initialize(ctx sdk.Context, clientID string, clientStateBz, consensusStateBz []byte) error {
@@ -43,7 +46,17 @@ func (l LightClientModule) Initialize(ctx sdk.Context, clientID string, clientSt
 		return err
 	}
 
+	if err := l.keeper.SelfValidator().ValidateSelfClient(&clientState, &consensusState); err != nil {
+		return errorsmod.Wrap(err, "self client validation failed")
+	}
+
 	store := l.keeper.ClientStore(ctx, clientID)
+
+	if registry := l.keeper.EphemeralRegistry(); registry != nil {
+		clientState.ConsensusState = &consensusState
+		return storeEphemeralClientState(l.keeper.Codec(), registry, store, clientState)
+	}
+
 	return clientState.Initialize(ctx, l.keeper.Codec(), store, &consensusState)
 }
 
@@ -57,13 +70,8 @@ func (l LightClientModule) VerifyClientMessage(ctx sdk.Context, clientID string,
 	}
 
 	store := l.keeper.ClientStore(ctx, clientID)
-	bz := store.Get(host.ClientStateKey())
-	if len(bz) == 0 {
-		return fmt.Errorf("failed to retrieve client state for client ID: %s", clientID)
-	}
-
-	var clientState ClientState
-	if err := l.keeper.Codec().Unmarshal(bz, &clientState); err != nil {
+	clientState, err := loadClientState(l.keeper.Codec(), l.keeper.EphemeralRegistry(), store, clientID)
+	if err != nil {
 		return err
 	}
 
@@ -78,13 +86,8 @@ func (l LightClientModule) CheckForMisbehaviour(ctx sdk.Context, clientID string
 	}
 
 	store := l.keeper.ClientStore(ctx, clientID)
-	bz := store.Get(host.ClientStateKey())
-	if len(bz) == 0 {
-		panic(fmt.Errorf("failed to retrieve client state for client ID: %s", clientID))
-	}
-
-	var clientState ClientState
-	if err := l.keeper.Codec().Unmarshal(bz, &clientState); err != nil {
+	clientState, err := loadClientState(l.keeper.Codec(), l.keeper.EphemeralRegistry(), store, clientID)
+	if err != nil {
 		panic(err)
 	}
 
@@ -98,13 +101,26 @@ func (l LightClientModule) UpdateStateOnMisbehaviour(ctx sdk.Context, clientID s
 	}
 
 	store := l.keeper.ClientStore(ctx, clientID)
-	bz := store.Get(host.ClientStateKey())
-	if len(bz) == 0 {
-		panic(fmt.Errorf("failed to retrieve client state for client ID: %s", clientID))
+
+	// In ephemeral mode, only the per-client delta is frozen; the (shared, template-backed)
+	// ConsensusState is never rewritten, and the legacy full ClientState key must not be written
+	// to either, or the client would end up stored under both representations at once.
+	if registry := l.keeper.EphemeralRegistry(); registry != nil {
+		if deltaBz := store.Get(clientStateDeltaKey); len(deltaBz) != 0 {
+			var delta ClientStateDelta
+			if err := l.keeper.Codec().Unmarshal(deltaBz, &delta); err != nil {
+				panic(err)
+			}
+
+			delta.IsFrozen = true
+			store.Set(clientStateDeltaKey, l.keeper.Codec().MustMarshal(&delta))
+
+			return
+		}
 	}
 
-	var clientState ClientState
-	if err := l.keeper.Codec().Unmarshal(bz, &clientState); err != nil {
+	clientState, err := loadClientState(l.keeper.Codec(), l.keeper.EphemeralRegistry(), store, clientID)
+	if err != nil {
 		panic(err)
 	}
 
@@ -119,17 +135,26 @@ func (l LightClientModule) UpdateState(ctx sdk.Context, clientID string, clientM
 	}
 
 	store := l.keeper.ClientStore(ctx, clientID)
-	bz := store.Get(host.ClientStateKey())
-	if len(bz) == 0 {
-		panic(fmt.Errorf("failed to retrieve client state for client ID: %s", clientID))
+	clientState, err := loadClientState(l.keeper.Codec(), l.keeper.EphemeralRegistry(), store, clientID)
+	if err != nil {
+		panic(err)
 	}
 
-	var clientState ClientState
-	if err := l.keeper.Codec().Unmarshal(bz, &clientState); err != nil {
-		panic(err)
+	if registry := l.keeper.EphemeralRegistry(); registry != nil {
+		if deltaBz := store.Get(clientStateDeltaKey); len(deltaBz) != 0 {
+			updated, heights := clientState.UpdateState(ctx, l.keeper.Codec(), store, clientMsg)
+			if err := storeEphemeralClientState(l.keeper.Codec(), registry, store, updated); err != nil {
+				panic(err)
+			}
+
+			return heights
+		}
 	}
 
-	return clientState.UpdateState(ctx, l.keeper.Codec(), store, clientMsg)
+	updated, heights := clientState.UpdateState(ctx, l.keeper.Codec(), store, clientMsg)
+	store.Set(host.ClientStateKey(), clienttypes.MustMarshalClientState(l.keeper.Codec(), &updated))
+
+	return heights
 }
 
 // VerifyMembership is a generic proof verification method which verifies a proof of the existence of a value at a given CommitmentPath at the specified height.
@@ -149,13 +174,8 @@ func (l LightClientModule) VerifyMembership(
 	}
 
 	store := l.keeper.ClientStore(ctx, clientID)
-	bz := store.Get(host.ClientStateKey())
-	if len(bz) == 0 {
-		return fmt.Errorf("failed to retrieve client state for client ID: %s", clientID)
-	}
-
-	var clientState ClientState
-	if err := l.keeper.Codec().Unmarshal(bz, &clientState); err != nil {
+	clientState, err := loadClientState(l.keeper.Codec(), l.keeper.EphemeralRegistry(), store, clientID)
+	if err != nil {
 		return err
 	}
 
@@ -178,13 +198,8 @@ func (l LightClientModule) VerifyNonMembership(
 	}
 
 	store := l.keeper.ClientStore(ctx, clientID)
-	bz := store.Get(host.ClientStateKey())
-	if len(bz) == 0 {
-		return fmt.Errorf("failed to retrieve client state for client ID: %s", clientID)
-	}
-
-	var clientState ClientState
-	if err := l.keeper.Codec().Unmarshal(bz, &clientState); err != nil {
+	clientState, err := loadClientState(l.keeper.Codec(), l.keeper.EphemeralRegistry(), store, clientID)
+	if err != nil {
 		return err
 	}
 
@@ -198,13 +213,8 @@ func (l LightClientModule) Status(ctx sdk.Context, clientID string) exported.Sta
 	}
 
 	store := l.keeper.ClientStore(ctx, clientID)
-	bz := store.Get(host.ClientStateKey())
-	if len(bz) == 0 {
-		panic(fmt.Errorf("failed to retrieve client state for client ID: %s", clientID))
-	}
-
-	var clientState ClientState
-	if err := l.keeper.Codec().Unmarshal(bz, &clientState); err != nil {
+	clientState, err := loadClientState(l.keeper.Codec(), l.keeper.EphemeralRegistry(), store, clientID)
+	if err != nil {
 		panic(err)
 	}
 
@@ -218,13 +228,8 @@ func (l LightClientModule) TimestampAtHeight(ctx sdk.Context, clientID string, h
 	}
 
 	store := l.keeper.ClientStore(ctx, clientID)
-	bz := store.Get(host.ClientStateKey())
-	if len(bz) == 0 {
-		return 0, fmt.Errorf("failed to retrieve client state for client ID: %s", clientID)
-	}
-
-	var clientState ClientState
-	if err := l.keeper.Codec().Unmarshal(bz, &clientState); err != nil {
+	clientState, err := loadClientState(l.keeper.Codec(), l.keeper.EphemeralRegistry(), store, clientID)
+	if err != nil {
 		return 0, err
 	}
 
@@ -244,24 +249,67 @@ func validateClientID(clientID string) error {
 	return nil
 }
 
-// // CheckSubstituteAndUpdateState must verify that the provided substitute may be used to update the subject client.
-// // The light client must set the updated client and consensus states within the clientStore for the subject client.
-// // DEPRECATED: will be removed as performs internal functionality
-// RecoverClient(ctx sdk.Context, clientID, substituteClientID string) error
-
-// // Upgrade functions
-// // NOTE: proof heights are not included as upgrade to a new revision is expected to pass only on the last
-// // height committed by the current revision. Clients are responsible for ensuring that the planned last
-// // height of the current revision is somehow encoded in the proof verification process.
-// // This is to ensure that no premature upgrades occur, since upgrade plans committed to by the counterparty
-// // may be cancelled or modified before the last planned height.
-// // If the upgrade is verified, the upgraded client and consensus states must be set in the client store.
-// // DEPRECATED: will be removed as performs internal functionality
-// VerifyUpgradeAndUpdateState(
-// 	ctx sdk.Context,
-// 	clientID string,
-// 	newClient []byte,
-// 	newConsState []byte,
-// 	upgradeClientProof,
-// 	upgradeConsensusStateProof []byte,
-// ) error
+// RecoverClient must verify that the substitute client is a solo machine client, that the subject client is
+// eligible for recovery (frozen or expired), and that the subject and substitute are both loadable from their
+// respective client stores. Upon success, the substitute's ConsensusState, Sequence, and IsFrozen fields are
+// copied into the subject client state, unfreezing the subject. Solo machines have no notion of a height-based
+// "substitute" update loop like Tendermint, so the only checks that matter here are client type and status.
+func (l LightClientModule) RecoverClient(ctx sdk.Context, clientID, substituteClientID string) error {
+	if err := validateClientID(clientID); err != nil {
+		return err
+	}
+
+	substituteClientType, _, err := clienttypes.ParseClientIdentifier(substituteClientID)
+	if err != nil {
+		return err
+	}
+	if substituteClientType != exported.Solomachine {
+		return errorsmod.Wrapf(clienttypes.ErrInvalidClientType, "substitute client must be a solo machine client, expected: %s, got: %s", exported.Solomachine, substituteClientType)
+	}
+
+	store := l.keeper.ClientStore(ctx, clientID)
+	clientState, err := loadClientState(l.keeper.Codec(), l.keeper.EphemeralRegistry(), store, clientID)
+	if err != nil {
+		return errorsmod.Wrap(err, "subject client")
+	}
+
+	substituteStore := l.keeper.ClientStore(ctx, substituteClientID)
+	substituteClientState, err := loadClientState(l.keeper.Codec(), l.keeper.EphemeralRegistry(), substituteStore, substituteClientID)
+	if err != nil {
+		return errorsmod.Wrap(err, "substitute client")
+	}
+
+	status := clientState.Status(ctx, store, l.keeper.Codec())
+	if status != exported.Frozen && status != exported.Expired {
+		return errorsmod.Wrapf(clienttypes.ErrInvalidRecoveryClient, "cannot recover client with status %s", status)
+	}
+
+	clientState.ConsensusState = substituteClientState.ConsensusState
+	clientState.Sequence = substituteClientState.Sequence
+	clientState.IsFrozen = false
+
+	if registry := l.keeper.EphemeralRegistry(); registry != nil {
+		if deltaBz := store.Get(clientStateDeltaKey); len(deltaBz) != 0 {
+			return storeEphemeralClientState(l.keeper.Codec(), registry, store, clientState)
+		}
+	}
+
+	store.Set(host.ClientStateKey(), clienttypes.MustMarshalClientState(l.keeper.Codec(), &clientState))
+
+	return nil
+}
+
+// VerifyUpgradeAndUpdateState, unlike Tendermint, has no notion of a height-based chain upgrade: a solo machine
+// is identified by its public key rather than by a counterparty chain's upgrade plan, so there is nothing for an
+// upgrade proof to verify against. It returns ErrInvalidUpgradeClient rather than panicking so that callers going
+// through the standard MsgUpgradeClient handler receive a normal IBC error.
+func (LightClientModule) VerifyUpgradeAndUpdateState(
+	ctx sdk.Context,
+	clientID string,
+	newClient []byte,
+	newConsState []byte,
+	upgradeClientProof,
+	upgradeConsensusStateProof []byte,
+) error {
+	return errorsmod.Wrap(clienttypes.ErrInvalidUpgradeClient, "cannot upgrade solomachine client")
+}
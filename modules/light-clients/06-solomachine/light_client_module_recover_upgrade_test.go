@@ -0,0 +1,57 @@
+package solomachine_test
+
+import (
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
+	"github.com/cosmos/ibc-go/v8/modules/core/exported"
+	solomachine "github.com/cosmos/ibc-go/v8/modules/light-clients/06-solomachine"
+)
+
+// TestRecoverClient covers the happy path where a frozen subject solo machine client is recovered
+// using a healthy substitute client: the subject's ConsensusState, Sequence, and IsFrozen fields
+// should be replaced with the substitute's and the client unfrozen.
+func (suite *SoloMachineTestSuite) TestRecoverClient() {
+	lightClientModule := suite.chainA.App.GetIBCKeeper().ClientKeeper.Route(exported.Solomachine)
+
+	subject, subjectClientState := suite.solomachine.CreateClient(suite.chainA)
+	substitute, substituteClientState := suite.solomachine.CreateClient(suite.chainA)
+
+	// freeze the subject client
+	subjectClientState.IsFrozen = true
+	suite.solomachine.SetClientState(suite.chainA, subject, subjectClientState)
+
+	err := lightClientModule.RecoverClient(suite.chainA.GetContext(), subject, substitute)
+	suite.Require().NoError(err)
+
+	store := suite.chainA.App.GetIBCKeeper().ClientKeeper.ClientStore(suite.chainA.GetContext(), subject)
+	bz := store.Get(host.ClientStateKey())
+	suite.Require().NotEmpty(bz)
+
+	var recovered solomachine.ClientState
+	suite.Require().NoError(suite.chainA.Codec.Unmarshal(bz, &recovered))
+	suite.Require().False(recovered.IsFrozen)
+	suite.Require().Equal(substituteClientState.Sequence, recovered.Sequence)
+	suite.Require().Equal(substituteClientState.ConsensusState, recovered.ConsensusState)
+}
+
+// TestRecoverClient_MismatchedClientType asserts that a substitute belonging to a different
+// client type (e.g. 07-tendermint) is rejected.
+func (suite *SoloMachineTestSuite) TestRecoverClient_MismatchedClientType() {
+	lightClientModule := suite.chainA.App.GetIBCKeeper().ClientKeeper.Route(exported.Solomachine)
+
+	subject, _ := suite.solomachine.CreateClient(suite.chainA)
+
+	err := lightClientModule.RecoverClient(suite.chainA.GetContext(), subject, "07-tendermint-0")
+	suite.Require().ErrorIs(err, clienttypes.ErrInvalidClientType)
+}
+
+// TestVerifyUpgradeAndUpdateState asserts that solo machine clients, which have no notion of a
+// height-based chain upgrade, always reject MsgUpgradeClient with ErrInvalidUpgradeClient.
+func (suite *SoloMachineTestSuite) TestVerifyUpgradeAndUpdateState() {
+	lightClientModule := suite.chainA.App.GetIBCKeeper().ClientKeeper.Route(exported.Solomachine)
+
+	clientID, _ := suite.solomachine.CreateClient(suite.chainA)
+
+	err := lightClientModule.VerifyUpgradeAndUpdateState(suite.chainA.GetContext(), clientID, nil, nil, nil, nil)
+	suite.Require().ErrorIs(err, clienttypes.ErrInvalidUpgradeClient)
+}
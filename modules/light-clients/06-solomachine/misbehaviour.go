@@ -0,0 +1,199 @@
+package solomachine
+
+import (
+	errorsmod "cosmossdk.io/errors"
+
+	"github.com/cosmos/ibc-go/v8/modules/core/exported"
+)
+
+var _ exported.ClientMessage = (*Misbehaviour)(nil)
+
+// DataType indicates what the SignatureAndData's Data field signs over.
+type DataType int32
+
+// SignatureAndData contains a signature along with the data it was expected to sign over.
+type SignatureAndData struct {
+	Signature []byte   `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature"`
+	DataType  DataType `protobuf:"varint,2,opt,name=data_type,json=dataType,proto3,enum=ibc.lightclients.solomachine.v3.DataType" json:"data_type"`
+	Data      []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data"`
+	Timestamp uint64   `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp"`
+}
+
+// Marshal implements the gogoproto Marshaler interface. See wire.go.
+func (s *SignatureAndData) Marshal() ([]byte, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	var dst []byte
+	if len(s.Signature) != 0 {
+		dst = appendBytesField(dst, 1, s.Signature)
+	}
+	if s.DataType != 0 {
+		dst = appendVarintField(dst, 2, uint64(s.DataType))
+	}
+	if len(s.Data) != 0 {
+		dst = appendBytesField(dst, 3, s.Data)
+	}
+	if s.Timestamp != 0 {
+		dst = appendVarintField(dst, 4, s.Timestamp)
+	}
+
+	return dst, nil
+}
+
+// Size implements the gogoproto Sizer interface. See wire.go.
+func (s *SignatureAndData) Size() int {
+	if s == nil {
+		return 0
+	}
+
+	var n int
+	if len(s.Signature) != 0 {
+		n += sizeBytesField(1, s.Signature)
+	}
+	if s.DataType != 0 {
+		n += sizeVarintField(2, uint64(s.DataType))
+	}
+	if len(s.Data) != 0 {
+		n += sizeBytesField(3, s.Data)
+	}
+	if s.Timestamp != 0 {
+		n += sizeVarintField(4, s.Timestamp)
+	}
+
+	return n
+}
+
+// Unmarshal implements the gogoproto Unmarshaler interface. See wire.go.
+func (s *SignatureAndData) Unmarshal(bz []byte) error {
+	*s = SignatureAndData{}
+
+	for len(bz) > 0 {
+		f, rest, err := nextField(bz)
+		if err != nil {
+			return err
+		}
+		bz = rest
+
+		switch f.num {
+		case 1:
+			s.Signature = append([]byte(nil), f.bytes...)
+		case 2:
+			s.DataType = DataType(f.varint)
+		case 3:
+			s.Data = append([]byte(nil), f.bytes...)
+		case 4:
+			s.Timestamp = f.varint
+		}
+	}
+
+	return nil
+}
+
+// Misbehaviour defines evidence that a solo machine client's owner signed two conflicting
+// messages at the same sequence. SignatureOne and SignatureTwo must be signed by the same public
+// key over differing data at the same Sequence for the evidence to be valid.
+type Misbehaviour struct {
+	Sequence     uint64            `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence"`
+	SignatureOne *SignatureAndData `protobuf:"bytes,2,opt,name=signature_one,json=signatureOne,proto3" json:"signature_one"`
+	SignatureTwo *SignatureAndData `protobuf:"bytes,3,opt,name=signature_two,json=signatureTwo,proto3" json:"signature_two"`
+}
+
+// Marshal implements the gogoproto Marshaler interface. See wire.go.
+func (m *Misbehaviour) Marshal() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	var dst []byte
+	if m.Sequence != 0 {
+		dst = appendVarintField(dst, 1, m.Sequence)
+	}
+	if m.SignatureOne != nil {
+		bz, err := m.SignatureOne.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		dst = appendBytesField(dst, 2, bz)
+	}
+	if m.SignatureTwo != nil {
+		bz, err := m.SignatureTwo.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		dst = appendBytesField(dst, 3, bz)
+	}
+
+	return dst, nil
+}
+
+// Size implements the gogoproto Sizer interface. See wire.go.
+func (m *Misbehaviour) Size() int {
+	if m == nil {
+		return 0
+	}
+
+	var n int
+	if m.Sequence != 0 {
+		n += sizeVarintField(1, m.Sequence)
+	}
+	if m.SignatureOne != nil {
+		n += sizeEmbedded(2, m.SignatureOne.Size())
+	}
+	if m.SignatureTwo != nil {
+		n += sizeEmbedded(3, m.SignatureTwo.Size())
+	}
+
+	return n
+}
+
+// Unmarshal implements the gogoproto Unmarshaler interface. See wire.go.
+func (m *Misbehaviour) Unmarshal(bz []byte) error {
+	*m = Misbehaviour{}
+
+	for len(bz) > 0 {
+		f, rest, err := nextField(bz)
+		if err != nil {
+			return err
+		}
+		bz = rest
+
+		switch f.num {
+		case 1:
+			m.Sequence = f.varint
+		case 2:
+			var inner SignatureAndData
+			if err := inner.Unmarshal(f.bytes); err != nil {
+				return err
+			}
+			m.SignatureOne = &inner
+		case 3:
+			var inner SignatureAndData
+			if err := inner.Unmarshal(f.bytes); err != nil {
+				return err
+			}
+			m.SignatureTwo = &inner
+		}
+	}
+
+	return nil
+}
+
+// ClientType returns Solo Machine type.
+func (Misbehaviour) ClientType() string {
+	return exported.Solomachine
+}
+
+// ValidateBasic ensures that both signatures are present and differ from one another.
+func (m Misbehaviour) ValidateBasic() error {
+	if m.SignatureOne == nil || m.SignatureTwo == nil {
+		return errorsmod.Wrap(ErrInvalidClientMessage, "misbehaviour signatures cannot be nil")
+	}
+
+	if string(m.SignatureOne.Data) == string(m.SignatureTwo.Data) {
+		return errorsmod.Wrap(ErrInvalidClientMessage, "misbehaviour signatures must sign over differing data")
+	}
+
+	return nil
+}
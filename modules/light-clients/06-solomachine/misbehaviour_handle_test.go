@@ -0,0 +1,101 @@
+package solomachine_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	solomachine "github.com/cosmos/ibc-go/v8/modules/light-clients/06-solomachine"
+)
+
+// signMisbehaviourData produces the signature a solo machine owner would produce for misbehaviour
+// evidence: data signed over the SignBytes envelope for misbehaviourPath, at the given sequence
+// and timestamp.
+func signMisbehaviourData(t *testing.T, privKey cryptotypes.PrivKey, diversifier string, sequence, timestamp uint64, data []byte) []byte {
+	t.Helper()
+
+	bz, err := solomachine.SignBytesForTest(sequence, timestamp, diversifier, solomachine.MisbehaviourPathForTest().String(), data)
+	require.NoError(t, err)
+
+	sig, err := privKey.Sign(bz)
+	require.NoError(t, err)
+
+	return sig
+}
+
+func TestVerifyMisbehaviour_Valid(t *testing.T) {
+	privKey := secp256k1.GenPrivKey()
+	pubKeyAny, err := codectypes.NewAnyWithValue(privKey.PubKey())
+	require.NoError(t, err)
+
+	clientState := solomachine.ClientState{
+		Sequence: 1,
+		ConsensusState: &solomachine.ConsensusState{
+			PublicKey:   pubKeyAny,
+			Diversifier: "ibc",
+			Timestamp:   10,
+		},
+	}
+
+	dataOne, dataTwo := []byte("data one"), []byte("data two")
+
+	misbehaviour := solomachine.Misbehaviour{
+		Sequence: 1,
+		SignatureOne: &solomachine.SignatureAndData{
+			Data:      dataOne,
+			Timestamp: 10,
+			Signature: signMisbehaviourData(t, privKey, "ibc", 1, 10, dataOne),
+		},
+		SignatureTwo: &solomachine.SignatureAndData{
+			Data:      dataTwo,
+			Timestamp: 10,
+			Signature: signMisbehaviourData(t, privKey, "ibc", 1, 10, dataTwo),
+		},
+	}
+
+	err = solomachine.VerifyMisbehaviourForTest(sdk.Context{}, clientState, misbehaviour)
+	require.NoError(t, err)
+}
+
+// TestVerifyMisbehaviour_RejectsReplayedSignature asserts that a signature produced by the same
+// key for an unrelated purpose (a different sequence) cannot be replayed as misbehaviour evidence
+// against the current sequence, even though it verifies under the public key directly.
+func TestVerifyMisbehaviour_RejectsReplayedSignature(t *testing.T) {
+	privKey := secp256k1.GenPrivKey()
+	pubKeyAny, err := codectypes.NewAnyWithValue(privKey.PubKey())
+	require.NoError(t, err)
+
+	clientState := solomachine.ClientState{
+		Sequence: 1,
+		ConsensusState: &solomachine.ConsensusState{
+			PublicKey:   pubKeyAny,
+			Diversifier: "ibc",
+			Timestamp:   10,
+		},
+	}
+
+	dataOne, dataTwo := []byte("data one"), []byte("data two")
+
+	misbehaviour := solomachine.Misbehaviour{
+		Sequence: 1,
+		SignatureOne: &solomachine.SignatureAndData{
+			Data:      dataOne,
+			Timestamp: 10,
+			// signed at a different sequence than the misbehaviour claims.
+			Signature: signMisbehaviourData(t, privKey, "ibc", 2, 10, dataOne),
+		},
+		SignatureTwo: &solomachine.SignatureAndData{
+			Data:      dataTwo,
+			Timestamp: 10,
+			Signature: signMisbehaviourData(t, privKey, "ibc", 1, 10, dataTwo),
+		},
+	}
+
+	err = solomachine.VerifyMisbehaviourForTest(sdk.Context{}, clientState, misbehaviour)
+	require.ErrorIs(t, err, solomachine.ErrSignatureVerificationFailed)
+}
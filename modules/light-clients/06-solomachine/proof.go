@@ -0,0 +1,196 @@
+package solomachine
+
+import (
+	errorsmod "cosmossdk.io/errors"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/ibc-go/v8/modules/core/exported"
+)
+
+// SignBytes defines the signed bytes used in verification of proofs against a solo machine
+// client. The sequence, timestamp, and diversifier tie a signature to a specific point in the
+// solo machine's signing history; the path and value commit it to a specific IBC store entry.
+type SignBytes struct {
+	Sequence    uint64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence"`
+	Timestamp   uint64 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp"`
+	Diversifier string `protobuf:"bytes,3,opt,name=diversifier,proto3" json:"diversifier"`
+	Path        []byte `protobuf:"bytes,4,opt,name=path,proto3" json:"path"`
+	Data        []byte `protobuf:"bytes,5,opt,name=data,proto3" json:"data"`
+}
+
+// Marshal implements the gogoproto Marshaler interface. See wire.go.
+func (sb *SignBytes) Marshal() ([]byte, error) {
+	if sb == nil {
+		return nil, nil
+	}
+
+	var dst []byte
+	if sb.Sequence != 0 {
+		dst = appendVarintField(dst, 1, sb.Sequence)
+	}
+	if sb.Timestamp != 0 {
+		dst = appendVarintField(dst, 2, sb.Timestamp)
+	}
+	if sb.Diversifier != "" {
+		dst = appendStringField(dst, 3, sb.Diversifier)
+	}
+	if len(sb.Path) != 0 {
+		dst = appendBytesField(dst, 4, sb.Path)
+	}
+	if len(sb.Data) != 0 {
+		dst = appendBytesField(dst, 5, sb.Data)
+	}
+
+	return dst, nil
+}
+
+// Size implements the gogoproto Sizer interface. See wire.go.
+func (sb *SignBytes) Size() int {
+	if sb == nil {
+		return 0
+	}
+
+	var n int
+	if sb.Sequence != 0 {
+		n += sizeVarintField(1, sb.Sequence)
+	}
+	if sb.Timestamp != 0 {
+		n += sizeVarintField(2, sb.Timestamp)
+	}
+	if sb.Diversifier != "" {
+		n += sizeStringField(3, sb.Diversifier)
+	}
+	if len(sb.Path) != 0 {
+		n += sizeBytesField(4, sb.Path)
+	}
+	if len(sb.Data) != 0 {
+		n += sizeBytesField(5, sb.Data)
+	}
+
+	return n
+}
+
+// Unmarshal implements the gogoproto Unmarshaler interface. See wire.go.
+func (sb *SignBytes) Unmarshal(bz []byte) error {
+	*sb = SignBytes{}
+
+	for len(bz) > 0 {
+		f, rest, err := nextField(bz)
+		if err != nil {
+			return err
+		}
+		bz = rest
+
+		switch f.num {
+		case 1:
+			sb.Sequence = f.varint
+		case 2:
+			sb.Timestamp = f.varint
+		case 3:
+			sb.Diversifier = string(f.bytes)
+		case 4:
+			sb.Path = append([]byte(nil), f.bytes...)
+		case 5:
+			sb.Data = append([]byte(nil), f.bytes...)
+		}
+	}
+
+	return nil
+}
+
+// verifySignature checks that proof authorizes the consensus state's committed public key
+// material - a single cryptotypes.PubKey, or a ThresholdPublicKey committee - over the SignBytes
+// constructed from the given path, value, and the client state's current sequence, timestamp, and
+// diversifier.
+func (cs ClientState) verifySignature(ctx sdk.Context, path exported.Path, value, proof []byte) error {
+	if cs.ConsensusState == nil {
+		return errorsmod.Wrap(ErrInvalidHeader, "consensus state is nil")
+	}
+
+	return verifyProof(ctx, cs.ConsensusState.PublicKey, cs.ConsensusState.Diversifier, cs.Sequence, cs.ConsensusState.Timestamp, path, value, proof)
+}
+
+// verifyProof is the parameterized core of verifySignature. It is factored out so that batched
+// header verification (see header_batch.go) can check each entry's proof against the public key
+// material committed to by the *previous* entry in the batch, rather than always against the
+// client state's current consensus state. pubKeyAny is unpacked as a ThresholdPublicKey if it was
+// packed as one (see threshold.go); otherwise it is treated as a single cryptotypes.PubKey.
+func verifyProof(ctx sdk.Context, pubKeyAny *codectypes.Any, diversifier string, sequence, timestamp uint64, path exported.Path, value, proof []byte) error {
+	signBytes := SignBytes{
+		Sequence:    sequence,
+		Timestamp:   timestamp,
+		Diversifier: diversifier,
+		Path:        []byte(path.String()),
+		Data:        value,
+	}
+
+	bz, err := ModuleCdc.MarshalJSON(&signBytes)
+	if err != nil {
+		return err
+	}
+
+	if pubKeyAny == nil || pubKeyAny.GetCachedValue() == nil {
+		return errorsmod.Wrap(ErrInvalidPubKey, "public key cannot be nil")
+	}
+
+	if tpk, ok := pubKeyAny.GetCachedValue().(*ThresholdPublicKey); ok {
+		return verifyThresholdSignature(ctx, tpk, bz, proof)
+	}
+
+	publicKey, ok := pubKeyAny.GetCachedValue().(cryptotypes.PubKey)
+	if !ok {
+		return errorsmod.Wrapf(ErrInvalidPubKey, "public key is neither a cryptotypes.PubKey nor a ThresholdPublicKey: %T", pubKeyAny.GetCachedValue())
+	}
+
+	if !publicKey.VerifySignature(bz, proof) {
+		return ErrSignatureVerificationFailed
+	}
+
+	return nil
+}
+
+// VerifyMembership verifies a proof of the existence of a value at a given CommitmentPath.
+// Since a solo machine has no state beyond its own signing key, "membership" is defined as: the
+// solo machine (or, in threshold mode, at least Threshold of its participants) signed over the
+// given path and value at its current sequence.
+func (cs ClientState) VerifyMembership(
+	ctx sdk.Context,
+	_ storetypes.KVStore,
+	_ codec.BinaryCodec,
+	_ exported.Height,
+	_ uint64,
+	_ uint64,
+	proof []byte,
+	path exported.Path,
+	value []byte,
+) error {
+	if cs.IsFrozen {
+		return ErrInvalidClientMessage
+	}
+
+	if len(proof) == 0 {
+		return errorsmod.Wrap(ErrInvalidSignatureAndData, "proof cannot be empty")
+	}
+
+	return cs.verifySignature(ctx, path, value, proof)
+}
+
+// VerifyNonMembership verifies a proof of the absence of a value at a given CommitmentPath: the
+// solo machine signed over the given path and an empty value at its current sequence.
+func (cs ClientState) VerifyNonMembership(
+	ctx sdk.Context,
+	store storetypes.KVStore,
+	cdc codec.BinaryCodec,
+	height exported.Height,
+	delayTimePeriod uint64,
+	delayBlockPeriod uint64,
+	proof []byte,
+	path exported.Path,
+) error {
+	return cs.VerifyMembership(ctx, store, cdc, height, delayTimePeriod, delayBlockPeriod, proof, path, nil)
+}
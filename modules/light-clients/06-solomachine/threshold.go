@@ -0,0 +1,399 @@
+package solomachine
+
+import (
+	"bytes"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+
+	tmcrypto "github.com/cometbft/cometbft/crypto"
+)
+
+// ThresholdPublicKey commits a solo machine's signing authority to a t-of-n committee rather than
+// a single key, for custodians that want multiple signers to co-authorize client updates and
+// proofs. ConsensusState.PublicKey may be packed with either a single cryptotypes.PubKey (the
+// pre-existing single-signer mode) or a *ThresholdPublicKey; GetThresholdPubKey reports which.
+//
+// ThresholdPublicKey itself implements cryptotypes.PubKey (see Address/Bytes/Equals/Type/
+// VerifySignature below) purely so it can be registered on, and resolved through, an
+// InterfaceRegistry the same way every other public key type ConsensusState.PublicKey may hold
+// is - see RegisterInterfaces in codec.go. Signature verification on the actual proof-checking
+// path still goes through verifyThresholdSignature directly (see proof.go), which meters gas
+// proportional to committee size; ThresholdPublicKey.VerifySignature exists only to satisfy the
+// interface and is not on that path.
+type ThresholdPublicKey struct {
+	Threshold    uint32            `protobuf:"varint,1,opt,name=threshold,proto3" json:"threshold"`
+	Participants []*codectypes.Any `protobuf:"bytes,2,rep,name=participants,proto3" json:"participants"`
+}
+
+var (
+	_ cryptotypes.PubKey                 = (*ThresholdPublicKey)(nil)
+	_ codectypes.UnpackInterfacesMessage = (*ThresholdPublicKey)(nil)
+)
+
+func (tpk *ThresholdPublicKey) Reset()         { *tpk = ThresholdPublicKey{} }
+func (tpk *ThresholdPublicKey) String() string { return "" }
+func (*ThresholdPublicKey) ProtoMessage()      {}
+
+// UnpackInterfaces implements codectypes.UnpackInterfacesMessage, resolving every participant's
+// concrete cryptotypes.PubKey type once this ThresholdPublicKey has been unmarshalled through a
+// codec whose InterfaceRegistry has RegisterInterfaces applied.
+func (tpk ThresholdPublicKey) UnpackInterfaces(unpacker codectypes.AnyUnpacker) error {
+	for _, p := range tpk.Participants {
+		var pubKey cryptotypes.PubKey
+		if err := unpacker.UnpackAny(p, &pubKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Marshal implements the gogoproto Marshaler interface. See wire.go.
+func (tpk *ThresholdPublicKey) Marshal() ([]byte, error) {
+	if tpk == nil {
+		return nil, nil
+	}
+
+	var dst []byte
+	if tpk.Threshold != 0 {
+		dst = appendVarintField(dst, 1, uint64(tpk.Threshold))
+	}
+	for _, p := range tpk.Participants {
+		bz, err := p.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		dst = appendBytesField(dst, 2, bz)
+	}
+
+	return dst, nil
+}
+
+// Size implements the gogoproto Sizer interface. See wire.go.
+func (tpk *ThresholdPublicKey) Size() int {
+	if tpk == nil {
+		return 0
+	}
+
+	var n int
+	if tpk.Threshold != 0 {
+		n += sizeVarintField(1, uint64(tpk.Threshold))
+	}
+	for _, p := range tpk.Participants {
+		n += sizeEmbedded(2, p.Size())
+	}
+
+	return n
+}
+
+// Unmarshal implements the gogoproto Unmarshaler interface. See wire.go.
+func (tpk *ThresholdPublicKey) Unmarshal(bz []byte) error {
+	*tpk = ThresholdPublicKey{}
+
+	for len(bz) > 0 {
+		f, rest, err := nextField(bz)
+		if err != nil {
+			return err
+		}
+		bz = rest
+
+		switch f.num {
+		case 1:
+			tpk.Threshold = uint32(f.varint)
+		case 2:
+			var any codectypes.Any
+			if err := any.Unmarshal(f.bytes); err != nil {
+				return err
+			}
+			tpk.Participants = append(tpk.Participants, &any)
+		}
+	}
+
+	return nil
+}
+
+// Bytes returns the proto-marshaled encoding of tpk, used as the canonical byte representation
+// for Address and Equals.
+func (tpk *ThresholdPublicKey) Bytes() []byte {
+	bz, err := tpk.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return bz
+}
+
+// Address returns a deterministic address derived from tpk's encoded bytes, following the same
+// AddressHash-over-Bytes() pattern as the SDK's other composite key types (e.g.
+// multisig.LegacyAminoPubKey), since a threshold committee has no single key of its own to derive
+// an address from.
+func (tpk *ThresholdPublicKey) Address() cryptotypes.Address {
+	return cryptotypes.Address(tmcrypto.AddressHash(tpk.Bytes()))
+}
+
+// Type returns a string identifying this as a solo machine threshold committee key, distinct from
+// any single-signer key type.
+func (*ThresholdPublicKey) Type() string {
+	return "solomachine-threshold"
+}
+
+// Equals reports whether other is a *ThresholdPublicKey with identical encoded bytes.
+func (tpk *ThresholdPublicKey) Equals(other cryptotypes.PubKey) bool {
+	o, ok := other.(*ThresholdPublicKey)
+	if !ok {
+		return false
+	}
+
+	return bytes.Equal(tpk.Bytes(), o.Bytes())
+}
+
+// VerifySignature reports whether sig decodes as a ThresholdSignatureData containing at least
+// Threshold valid, distinct-index participant signatures over msg. See the doc comment on
+// ThresholdPublicKey for why this exists alongside verifyThresholdSignature.
+func (tpk *ThresholdPublicKey) VerifySignature(msg, sig []byte) bool {
+	ok, err := thresholdSignaturesValid(tpk, msg, sig)
+	return err == nil && ok
+}
+
+// ValidateBasic ensures the threshold is reachable and every participant key is present.
+func (tpk ThresholdPublicKey) ValidateBasic() error {
+	if tpk.Threshold == 0 {
+		return errorsmod.Wrap(ErrInvalidPubKey, "threshold cannot be 0")
+	}
+
+	if int(tpk.Threshold) > len(tpk.Participants) {
+		return errorsmod.Wrapf(ErrInvalidPubKey, "threshold %d exceeds number of participants %d", tpk.Threshold, len(tpk.Participants))
+	}
+
+	for i, p := range tpk.Participants {
+		if p == nil || p.GetCachedValue() == nil {
+			return errorsmod.Wrapf(ErrInvalidPubKey, "participant at index %d is nil", i)
+		}
+	}
+
+	return nil
+}
+
+// participant returns the cryptotypes.PubKey for the participant at idx.
+func (tpk ThresholdPublicKey) participant(idx uint32) (cryptotypes.PubKey, error) {
+	if int(idx) >= len(tpk.Participants) {
+		return nil, errorsmod.Wrapf(ErrInvalidSignatureAndData, "participant index %d out of range (%d participants)", idx, len(tpk.Participants))
+	}
+
+	pubKey, ok := tpk.Participants[idx].GetCachedValue().(cryptotypes.PubKey)
+	if !ok {
+		return nil, errorsmod.Wrapf(ErrInvalidPubKey, "participant at index %d is not a cryptotypes.PubKey", idx)
+	}
+
+	return pubKey, nil
+}
+
+// GetThresholdPubKey reports whether the consensus state's PublicKey is a ThresholdPublicKey, and
+// returns it if so.
+func (cs ConsensusState) GetThresholdPubKey() (*ThresholdPublicKey, bool) {
+	if cs.PublicKey == nil {
+		return nil, false
+	}
+
+	tpk, ok := cs.PublicKey.GetCachedValue().(*ThresholdPublicKey)
+	return tpk, ok
+}
+
+// IndexedSignature pairs a signature with the index (into ThresholdPublicKey.Participants) of the
+// participant that produced it.
+type IndexedSignature struct {
+	Index     uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index"`
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature"`
+}
+
+// Marshal implements the gogoproto Marshaler interface. See wire.go.
+func (s *IndexedSignature) Marshal() ([]byte, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	var dst []byte
+	if s.Index != 0 {
+		dst = appendVarintField(dst, 1, uint64(s.Index))
+	}
+	if len(s.Signature) != 0 {
+		dst = appendBytesField(dst, 2, s.Signature)
+	}
+
+	return dst, nil
+}
+
+// Size implements the gogoproto Sizer interface. See wire.go.
+func (s *IndexedSignature) Size() int {
+	if s == nil {
+		return 0
+	}
+
+	var n int
+	if s.Index != 0 {
+		n += sizeVarintField(1, uint64(s.Index))
+	}
+	if len(s.Signature) != 0 {
+		n += sizeBytesField(2, s.Signature)
+	}
+
+	return n
+}
+
+// Unmarshal implements the gogoproto Unmarshaler interface. See wire.go.
+func (s *IndexedSignature) Unmarshal(bz []byte) error {
+	*s = IndexedSignature{}
+
+	for len(bz) > 0 {
+		f, rest, err := nextField(bz)
+		if err != nil {
+			return err
+		}
+		bz = rest
+
+		switch f.num {
+		case 1:
+			s.Index = uint32(f.varint)
+		case 2:
+			s.Signature = append([]byte(nil), f.bytes...)
+		}
+	}
+
+	return nil
+}
+
+// ThresholdSignatureData is the proof format used to verify membership/non-membership and header
+// rotation against a ThresholdPublicKey: a set of IndexedSignatures, each expected to verify
+// against the corresponding participant's public key over the same SignBytes a single signer
+// would produce for the same (sequence, timestamp, diversifier, path, value).
+type ThresholdSignatureData struct {
+	Signatures []IndexedSignature `protobuf:"bytes,1,rep,name=signatures,proto3" json:"signatures"`
+}
+
+func (d *ThresholdSignatureData) Reset()         { *d = ThresholdSignatureData{} }
+func (d *ThresholdSignatureData) String() string { return "" }
+func (*ThresholdSignatureData) ProtoMessage()    {}
+
+// Marshal implements the gogoproto Marshaler interface. See wire.go.
+func (d *ThresholdSignatureData) Marshal() ([]byte, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	var dst []byte
+	for i := range d.Signatures {
+		bz, err := d.Signatures[i].Marshal()
+		if err != nil {
+			return nil, err
+		}
+		dst = appendBytesField(dst, 1, bz)
+	}
+
+	return dst, nil
+}
+
+// Size implements the gogoproto Sizer interface. See wire.go.
+func (d *ThresholdSignatureData) Size() int {
+	if d == nil {
+		return 0
+	}
+
+	var n int
+	for i := range d.Signatures {
+		n += sizeEmbedded(1, d.Signatures[i].Size())
+	}
+
+	return n
+}
+
+// Unmarshal implements the gogoproto Unmarshaler interface. See wire.go.
+func (d *ThresholdSignatureData) Unmarshal(bz []byte) error {
+	*d = ThresholdSignatureData{}
+
+	for len(bz) > 0 {
+		f, rest, err := nextField(bz)
+		if err != nil {
+			return err
+		}
+		bz = rest
+
+		if f.num == 1 {
+			var sig IndexedSignature
+			if err := sig.Unmarshal(f.bytes); err != nil {
+				return err
+			}
+			d.Signatures = append(d.Signatures, sig)
+		}
+	}
+
+	return nil
+}
+
+// gasCostPerThresholdParticipant is charged once per participant in a ThresholdPublicKey for
+// every threshold proof verified, since verification cost scales with committee size rather than
+// being constant as it is for a single signer.
+const gasCostPerThresholdParticipant uint64 = 1000
+
+// thresholdSignaturesValid decodes proof as a ThresholdSignatureData and reports whether at least
+// tpk.Threshold of its IndexedSignatures verify against signBytes under distinct participant
+// indices. Duplicate indices are rejected outright, since otherwise a single participant's
+// signature could be replayed under multiple claimed indices to satisfy the threshold alone. It
+// does not meter gas; verifyThresholdSignature wraps it with gas metering proportional to
+// committee size for the actual proof-verification path.
+func thresholdSignaturesValid(tpk *ThresholdPublicKey, signBytes, proof []byte) (bool, error) {
+	if err := tpk.ValidateBasic(); err != nil {
+		return false, err
+	}
+
+	var sigData ThresholdSignatureData
+	if err := ModuleCdc.UnmarshalJSON(proof, &sigData); err != nil {
+		return false, errorsmod.Wrap(ErrInvalidSignatureAndData, "failed to unmarshal threshold signature data")
+	}
+
+	seen := make(map[uint32]bool, len(sigData.Signatures))
+	valid := 0
+
+	for _, indexed := range sigData.Signatures {
+		if seen[indexed.Index] {
+			return false, errorsmod.Wrapf(ErrInvalidSignatureAndData, "duplicate signature index %d", indexed.Index)
+		}
+		seen[indexed.Index] = true
+
+		pubKey, err := tpk.participant(indexed.Index)
+		if err != nil {
+			return false, err
+		}
+
+		if pubKey.VerifySignature(signBytes, indexed.Signature) {
+			valid++
+		}
+	}
+
+	return uint32(valid) >= tpk.Threshold, nil
+}
+
+// verifyThresholdSignature checks that proof decodes to a ThresholdSignatureData containing at
+// least tpk.Threshold valid, distinct-index signatures over signBytes.
+func verifyThresholdSignature(ctx sdk.Context, tpk *ThresholdPublicKey, signBytes, proof []byte) error {
+	if err := tpk.ValidateBasic(); err != nil {
+		return err
+	}
+
+	ctx.GasMeter().ConsumeGas(uint64(len(tpk.Participants))*gasCostPerThresholdParticipant, "solomachine threshold signature verification")
+
+	ok, err := thresholdSignaturesValid(tpk, signBytes, proof)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return errorsmod.Wrapf(ErrSignatureVerificationFailed, "fewer than %d threshold signatures verified", tpk.Threshold)
+	}
+
+	return nil
+}
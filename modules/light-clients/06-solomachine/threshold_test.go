@@ -0,0 +1,178 @@
+package solomachine_test
+
+import (
+	"testing"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/stretchr/testify/require"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	solomachine "github.com/cosmos/ibc-go/v8/modules/light-clients/06-solomachine"
+)
+
+// newThresholdCommittee generates n participant keys and packs them, together with threshold,
+// into a ThresholdPublicKey. It returns the private keys alongside the packed public key so tests
+// can sign with any subset of participants.
+func newThresholdCommittee(t *testing.T, threshold uint32, n int) ([]cryptotypes.PrivKey, *codectypes.Any) {
+	t.Helper()
+
+	privKeys := make([]cryptotypes.PrivKey, n)
+	participants := make([]*codectypes.Any, n)
+	for i := 0; i < n; i++ {
+		privKey := secp256k1.GenPrivKey()
+		privKeys[i] = privKey
+
+		any, err := codectypes.NewAnyWithValue(privKey.PubKey())
+		require.NoError(t, err)
+		participants[i] = any
+	}
+
+	tpk := &solomachine.ThresholdPublicKey{Threshold: threshold, Participants: participants}
+	tpkAny, err := codectypes.NewAnyWithValue(tpk)
+	require.NoError(t, err)
+
+	return privKeys, tpkAny
+}
+
+func newThresholdClientState(t *testing.T, threshold uint32, n int) ([]cryptotypes.PrivKey, solomachine.ClientState) {
+	t.Helper()
+
+	privKeys, tpkAny := newThresholdCommittee(t, threshold, n)
+
+	clientState := solomachine.ClientState{
+		Sequence: 1,
+		ConsensusState: &solomachine.ConsensusState{
+			PublicKey:   tpkAny,
+			Diversifier: "ibc",
+			Timestamp:   10,
+		},
+	}
+
+	return privKeys, clientState
+}
+
+// signThresholdMembership produces the IndexedSignature-based proof a threshold committee would
+// produce for a VerifyMembership call at the client state's current sequence.
+func signThresholdMembership(t *testing.T, cs solomachine.ClientState, path, value []byte, signers map[uint32]cryptotypes.PrivKey) []byte {
+	t.Helper()
+
+	bz, err := solomachine.SignBytesForTest(cs.Sequence, cs.ConsensusState.Timestamp, cs.ConsensusState.Diversifier, string(path), value)
+	require.NoError(t, err)
+
+	sigs := make([]solomachine.IndexedSignature, 0, len(signers))
+	for idx, privKey := range signers {
+		sig, err := privKey.Sign(bz)
+		require.NoError(t, err)
+		sigs = append(sigs, solomachine.IndexedSignature{Index: idx, Signature: sig})
+	}
+
+	proof, err := solomachine.ModuleCdc.MarshalJSON(&solomachine.ThresholdSignatureData{Signatures: sigs})
+	require.NoError(t, err)
+
+	return proof
+}
+
+func newGasMeteredContext(limit storetypes.Gas) sdk.Context {
+	return sdk.Context{}.WithGasMeter(storetypes.NewGasMeter(limit))
+}
+
+func TestVerifyMembership_Threshold_Valid(t *testing.T) {
+	privKeys, clientState := newThresholdClientState(t, 2, 3)
+
+	proof := signThresholdMembership(t, clientState, []byte("update"), []byte("value"), map[uint32]cryptotypes.PrivKey{
+		0: privKeys[0],
+		2: privKeys[2],
+	})
+
+	ctx := newGasMeteredContext(storetypes.Gas(1_000_000))
+	err := solomachine.VerifyMembershipForTest(ctx, clientState, solomachine.UpdatePathForTest("update"), []byte("value"), proof)
+	require.NoError(t, err)
+	require.Equal(t, storetypes.Gas(3000), ctx.GasMeter().GasConsumed())
+}
+
+func TestVerifyMembership_Threshold_BelowThreshold(t *testing.T) {
+	privKeys, clientState := newThresholdClientState(t, 2, 3)
+
+	proof := signThresholdMembership(t, clientState, []byte("update"), []byte("value"), map[uint32]cryptotypes.PrivKey{
+		0: privKeys[0],
+	})
+
+	ctx := newGasMeteredContext(storetypes.Gas(1_000_000))
+	err := solomachine.VerifyMembershipForTest(ctx, clientState, solomachine.UpdatePathForTest("update"), []byte("value"), proof)
+	require.ErrorIs(t, err, solomachine.ErrSignatureVerificationFailed)
+}
+
+func TestVerifyMembership_Threshold_DuplicateIndex(t *testing.T) {
+	privKeys, clientState := newThresholdClientState(t, 2, 3)
+
+	bz, err := solomachine.SignBytesForTest(clientState.Sequence, clientState.ConsensusState.Timestamp, clientState.ConsensusState.Diversifier, "update", []byte("value"))
+	require.NoError(t, err)
+
+	sig, err := privKeys[0].Sign(bz)
+	require.NoError(t, err)
+
+	proof, err := solomachine.ModuleCdc.MarshalJSON(&solomachine.ThresholdSignatureData{
+		Signatures: []solomachine.IndexedSignature{
+			{Index: 0, Signature: sig},
+			{Index: 0, Signature: sig},
+		},
+	})
+	require.NoError(t, err)
+
+	ctx := newGasMeteredContext(storetypes.Gas(1_000_000))
+	err = solomachine.VerifyMembershipForTest(ctx, clientState, solomachine.UpdatePathForTest("update"), []byte("value"), proof)
+	require.ErrorIs(t, err, solomachine.ErrInvalidSignatureAndData)
+}
+
+// TestHeaderRotation_ThresholdToSingleKey asserts that a threshold committee can rotate the
+// client's signing authority back down to a single key via an ordinary Header, and that a
+// ConsensusState holding that single key is no longer reported as a threshold committee.
+func TestHeaderRotation_ThresholdToSingleKey(t *testing.T) {
+	privKeys, clientState := newThresholdClientState(t, 2, 3)
+
+	newKey := secp256k1.GenPrivKey()
+	newPubKeyAny, err := codectypes.NewAnyWithValue(newKey.PubKey())
+	require.NoError(t, err)
+
+	data, err := solomachine.HeaderSignBytesDataForTest(newPubKeyAny, "ibc")
+	require.NoError(t, err)
+
+	bz, err := solomachine.SignBytesForTest(clientState.Sequence, clientState.ConsensusState.Timestamp, clientState.ConsensusState.Diversifier, "update", data)
+	require.NoError(t, err)
+
+	sigs := make([]solomachine.IndexedSignature, 0, 2)
+	for _, idx := range []uint32{0, 1} {
+		sig, err := privKeys[idx].Sign(bz)
+		require.NoError(t, err)
+		sigs = append(sigs, solomachine.IndexedSignature{Index: idx, Signature: sig})
+	}
+
+	proof, err := solomachine.ModuleCdc.MarshalJSON(&solomachine.ThresholdSignatureData{Signatures: sigs})
+	require.NoError(t, err)
+
+	header := solomachine.Header{
+		Sequence:       clientState.Sequence,
+		Timestamp:      clientState.ConsensusState.Timestamp,
+		NewPublicKey:   newPubKeyAny,
+		NewDiversifier: "ibc",
+		Signature:      proof,
+	}
+
+	ctx := newGasMeteredContext(storetypes.Gas(1_000_000))
+	err = solomachine.VerifyHeaderForTest(ctx, clientState, header)
+	require.NoError(t, err)
+
+	rotated := clientState
+	rotated.ConsensusState = &solomachine.ConsensusState{
+		PublicKey:   newPubKeyAny,
+		Diversifier: "ibc",
+		Timestamp:   clientState.ConsensusState.Timestamp,
+	}
+	_, isThreshold := rotated.ConsensusState.GetThresholdPubKey()
+	require.False(t, isThreshold)
+}
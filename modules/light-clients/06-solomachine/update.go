@@ -0,0 +1,125 @@
+package solomachine
+
+import (
+	"encoding/json"
+
+	errorsmod "cosmossdk.io/errors"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	host "github.com/cosmos/ibc-go/v8/modules/core/24-host"
+	"github.com/cosmos/ibc-go/v8/modules/core/exported"
+)
+
+// VerifyClientMessage must verify a ClientMessage. A ClientMessage could be a Header, a
+// Misbehaviour, or a batch update (HeaderBatch, see header_batch.go). It must handle each type of
+// ClientMessage appropriately. An error is returned if the ClientMessage fails to verify.
+func (cs ClientState) VerifyClientMessage(ctx sdk.Context, cdc codec.BinaryCodec, _ storetypes.KVStore, clientMsg exported.ClientMessage) error {
+	switch msg := clientMsg.(type) {
+	case *Header:
+		return cs.verifyHeader(ctx, cdc, *msg)
+	case *Misbehaviour:
+		return cs.verifyMisbehaviour(ctx, cdc, *msg)
+	case *HeaderBatch:
+		return cs.verifyHeaderBatch(ctx, *msg)
+	default:
+		return errorsmod.Wrapf(ErrInvalidClientMessage, "unsupported client message type %T", clientMsg)
+	}
+}
+
+// verifyHeader checks that the header's sequence matches the client state's current sequence and
+// that the header's signature authorizes the current public key material (a single signer, or a
+// ThresholdPublicKey committee) over the header's NewPublicKey and NewDiversifier. The new public
+// key committed to may itself be a ThresholdPublicKey, which is how a single-signer client rotates
+// into, or a threshold committee rotates into, a different committee or back to a single signer.
+func (cs ClientState) verifyHeader(ctx sdk.Context, _ codec.BinaryCodec, header Header) error {
+	if cs.IsFrozen {
+		return ErrInvalidClientMessage
+	}
+
+	if header.Sequence != cs.Sequence {
+		return errorsmod.Wrapf(ErrInvalidSequence, "header sequence does not match the client state sequence (%d != %d)", header.Sequence, cs.Sequence)
+	}
+
+	if header.Timestamp < cs.ConsensusState.Timestamp {
+		return errorsmod.Wrap(ErrInvalidHeader, "header timestamp is less than the consensus state timestamp")
+	}
+
+	data, err := headerSignBytesData(header.NewPublicKey, header.NewDiversifier)
+	if err != nil {
+		return err
+	}
+
+	return cs.verifySignature(ctx, headerUpdatePath, data, header.Signature)
+}
+
+// headerUpdatePath is a sentinel path used to namespace the signature produced when rotating a
+// solo machine's public key, distinct from the paths used for IBC packet/commitment proofs.
+var headerUpdatePath = updatePath("update")
+
+type updatePath string
+
+func (p updatePath) String() string { return string(p) }
+
+// headerSignBytesData encodes the new public key and diversifier that a Header (or a single
+// entry of a HeaderBatch) commits to.
+func headerSignBytesData(newPublicKey interface{ String() string }, newDiversifier string) ([]byte, error) {
+	return json.Marshal(&struct {
+		NewPublicKey   string `json:"new_public_key"`
+		NewDiversifier string `json:"new_diversifier"`
+	}{
+		NewPublicKey:   newPublicKey.String(),
+		NewDiversifier: newDiversifier,
+	})
+}
+
+// CheckForMisbehaviour returns true if the ClientMessage is a Misbehaviour (its ValidateBasic,
+// called during VerifyClientMessage's type switch in the caller, already confirmed the two
+// signatures are over differing data at the same sequence), or a HeaderBatch containing two
+// headers at the same sequence that were verified against the same prior key but disagree.
+func (ClientState) CheckForMisbehaviour(_ sdk.Context, _ codec.BinaryCodec, _ storetypes.KVStore, clientMsg exported.ClientMessage) bool {
+	switch msg := clientMsg.(type) {
+	case *Misbehaviour:
+		return true
+	case *HeaderBatch:
+		return headerBatchHasConflict(*msg)
+	default:
+		return false
+	}
+}
+
+// UpdateStateOnMisbehaviour freezes the client. Solo machine misbehaviour is never recoverable by
+// replaying history (there is none to replay), so freezing is the only valid response.
+func (cs ClientState) UpdateStateOnMisbehaviour(_ sdk.Context, cdc codec.BinaryCodec, clientStore storetypes.KVStore, _ exported.ClientMessage) {
+	cs.IsFrozen = true
+	clientStore.Set(host.ClientStateKey(), clienttypes.MustMarshalClientState(cdc, &cs))
+}
+
+// UpdateState rotates the public key/diversifier (if provided in the Header) and advances the
+// sequence by one, returning the resulting ClientState together with the consensus heights
+// produced, but does not itself persist anything: cs has a value receiver, so the caller must
+// store the returned ClientState (under the legacy ClientStateKey, or via storeEphemeralClientState
+// in ephemeral mode - see light_client_module.go). It assumes the ClientMessage has already been
+// verified by VerifyClientMessage. For a HeaderBatch, every header in the batch is applied in
+// order, but only the final ConsensusState is reflected in the returned ClientState; see
+// header_batch.go.
+func (cs ClientState) UpdateState(_ sdk.Context, _ codec.BinaryCodec, _ storetypes.KVStore, clientMsg exported.ClientMessage) (ClientState, []exported.Height) {
+	switch msg := clientMsg.(type) {
+	case *Header:
+		cs.Sequence++
+		cs.ConsensusState = &ConsensusState{
+			PublicKey:   msg.NewPublicKey,
+			Diversifier: msg.NewDiversifier,
+			Timestamp:   msg.Timestamp,
+		}
+
+		return cs, []exported.Height{clienttypes.ZeroHeight()}
+	case *HeaderBatch:
+		return cs.updateStateFromHeaderBatch(*msg)
+	default:
+		panic(errorsmod.Wrapf(ErrInvalidClientMessage, "expected type %T or %T, got %T", &Header{}, &HeaderBatch{}, clientMsg))
+	}
+}
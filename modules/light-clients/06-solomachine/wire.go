@@ -0,0 +1,142 @@
+package solomachine
+
+import "errors"
+
+// This file hand-implements the protobuf wire encoding that `make proto-gen` would otherwise
+// generate into solomachine.pb.go. There is no protoc toolchain available to generate that file,
+// so the Marshal/Unmarshal/Size methods on each type in this package (see header.go,
+// consensus_state.go, client_state.go, header_batch.go, misbehaviour.go, proof.go, and
+// threshold.go) are written by hand against the field numbers declared in
+// proto/ibc/lightclients/solomachine/v3/solomachine.proto, using the helpers below. The resulting
+// bytes are ordinary protobuf wire format, so they remain compatible with a real generated
+// solomachine.pb.go dropped in later.
+
+func encodeVarint(dst []byte, v uint64) []byte {
+	for v >= 0x80 {
+		dst = append(dst, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(dst, byte(v))
+}
+
+func sizeVarint(v uint64) int {
+	n := 1
+	for v >= 0x80 {
+		v >>= 7
+		n++
+	}
+	return n
+}
+
+// decodeVarint reads a varint off the front of buf, returning its value and the number of bytes
+// consumed.
+func decodeVarint(buf []byte) (uint64, int, error) {
+	var v uint64
+	var shift uint
+	for i := 0; i < len(buf); i++ {
+		b := buf[i]
+		if shift >= 64 {
+			return 0, 0, errors.New("solomachine: varint overflows uint64")
+		}
+		v |= uint64(b&0x7f) << shift
+		if b < 0x80 {
+			return v, i + 1, nil
+		}
+		shift += 7
+	}
+	return 0, 0, errors.New("solomachine: truncated varint")
+}
+
+func appendTag(dst []byte, fieldNum, wireType int) []byte {
+	return encodeVarint(dst, uint64(fieldNum)<<3|uint64(wireType))
+}
+
+func sizeTag(fieldNum int) int {
+	return sizeVarint(uint64(fieldNum) << 3)
+}
+
+func appendVarintField(dst []byte, fieldNum int, v uint64) []byte {
+	dst = appendTag(dst, fieldNum, 0)
+	return encodeVarint(dst, v)
+}
+
+func sizeVarintField(fieldNum int, v uint64) int {
+	return sizeTag(fieldNum) + sizeVarint(v)
+}
+
+func appendBoolField(dst []byte, fieldNum int, v bool) []byte {
+	var u uint64
+	if v {
+		u = 1
+	}
+	return appendVarintField(dst, fieldNum, u)
+}
+
+// appendBytesField also covers embedded messages and strings: all three share wire type 2
+// (length-delimited), so the caller need only marshal a nested message to bytes first.
+func appendBytesField(dst []byte, fieldNum int, b []byte) []byte {
+	dst = appendTag(dst, fieldNum, 2)
+	dst = encodeVarint(dst, uint64(len(b)))
+	return append(dst, b...)
+}
+
+func sizeBytesField(fieldNum int, b []byte) int {
+	return sizeTag(fieldNum) + sizeVarint(uint64(len(b))) + len(b)
+}
+
+func appendStringField(dst []byte, fieldNum int, s string) []byte {
+	return appendBytesField(dst, fieldNum, []byte(s))
+}
+
+func sizeStringField(fieldNum int, s string) int {
+	return sizeTag(fieldNum) + sizeVarint(uint64(len(s))) + len(s)
+}
+
+// sizeEmbedded reports the size contribution of an embedded message field given the marshaled
+// size of the nested message, without requiring the caller to marshal it first.
+func sizeEmbedded(fieldNum, size int) int {
+	return sizeTag(fieldNum) + sizeVarint(uint64(size)) + size
+}
+
+// wireField is one decoded (field number, wire type) pair read off the front of a marshaled
+// message. varint holds the decoded value for wire type 0; bytes holds the raw content for wire
+// type 2 (length-delimited: strings, bytes, and embedded messages alike). No field in this
+// package uses any other wire type.
+type wireField struct {
+	num    int
+	varint uint64
+	bytes  []byte
+}
+
+// nextField consumes one field from the front of buf, returning the remaining bytes.
+func nextField(buf []byte) (wireField, []byte, error) {
+	tag, n, err := decodeVarint(buf)
+	if err != nil {
+		return wireField{}, nil, err
+	}
+	buf = buf[n:]
+
+	fieldNum := int(tag >> 3)
+	wireType := int(tag & 0x7)
+
+	switch wireType {
+	case 0:
+		v, n, err := decodeVarint(buf)
+		if err != nil {
+			return wireField{}, nil, err
+		}
+		return wireField{num: fieldNum, varint: v}, buf[n:], nil
+	case 2:
+		length, n, err := decodeVarint(buf)
+		if err != nil {
+			return wireField{}, nil, err
+		}
+		buf = buf[n:]
+		if uint64(len(buf)) < length {
+			return wireField{}, nil, errors.New("solomachine: truncated length-delimited field")
+		}
+		return wireField{num: fieldNum, bytes: buf[:length]}, buf[length:], nil
+	default:
+		return wireField{}, nil, errors.New("solomachine: unsupported wire type")
+	}
+}